@@ -4,46 +4,238 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// Air quality domains, CAMS Europe offers an 11km resolution, CAMS global 40km
+const (
+	AirQualityDomainAuto       = "auto"
+	AirQualityDomainCAMSEurope = "cams_europe"
+	AirQualityDomainCAMSGlobal = "cams_global"
+)
+
+// AirQualityOptions holds the request options for the Air Quality API
+type AirQualityOptions struct {
+	HourlyMetrics  []string      // Lists required hourly metrics, see https://open-meteo.com/en/docs/air-quality-api for valid metrics
+	CurrentMetrics []string      // Lists required current metrics
+	Domains        string        // Default "auto", see the AirQualityDomain constants
+	Timezone       string        // Default "UTC"
+	PastDays       int           // Default 0
+	ForecastDays   int           // Default 5, up to 7
+	StartDate      string        // Start date (format: YYYY-MM-DD)
+	EndDate        string        // End date (format: YYYY-MM-DD)
+	CacheTTL       time.Duration // Overrides the Client's TTLPolicy for this request, negative disables caching
+}
+
+// AirQualityData holds the air quality values at a single point in time
 type AirQualityData struct {
-	PM10  float64 `json:"pm10"`
-	PM2_5 float64 `json:"pm2_5"`
-	O3    float64 `json:"o3"`
-	NO2   float64 `json:"no2"`
-	// Add more fields as needed
+	Time           ApiTime `json:"time"`
+	PM10           float64 `json:"pm10"`
+	PM2_5          float64 `json:"pm2_5"`
+	CarbonMonoxide float64 `json:"carbon_monoxide"`
+	NO2            float64 `json:"nitrogen_dioxide"`
+	SulphurDioxide float64 `json:"sulphur_dioxide"`
+	O3             float64 `json:"ozone"`
+	Dust           float64 `json:"dust"`
+	UVIndex        float64 `json:"uv_index"`
+	AlderPollen    float64 `json:"alder_pollen"`
+	BirchPollen    float64 `json:"birch_pollen"`
+	GrassPollen    float64 `json:"grass_pollen"`
+	MugwortPollen  float64 `json:"mugwort_pollen"`
+	OlivePollen    float64 `json:"olive_pollen"`
+	RagweedPollen  float64 `json:"ragweed_pollen"`
+	EuropeanAQI    float64 `json:"european_aqi"`
+	USAQI          float64 `json:"us_aqi"`
 }
 
-func (c Client) GetAirQuality(ctx context.Context, loc Location, opts *Options) (AirQualityData, error) {
-	if opts == nil {
-		opts = &Options{}
-	}
-	if len(opts.AirQualityMetrics) == 0 {
-		opts.AirQualityMetrics = []string{"pm10", "pm2_5", "o3", "no2"}
-	}
+// AirQualityHourlyData holds the hourly air quality time series
+type AirQualityHourlyData struct {
+	Time           []time.Time
+	PM10           []float64
+	PM2_5          []float64
+	CarbonMonoxide []float64
+	NO2            []float64
+	SulphurDioxide []float64
+	O3             []float64
+	Dust           []float64
+	UVIndex        []float64
+	AlderPollen    []float64
+	BirchPollen    []float64
+	GrassPollen    []float64
+	MugwortPollen  []float64
+	OlivePollen    []float64
+	RagweedPollen  []float64
+	EuropeanAQI    []float64
+	USAQI          []float64
+}
+
+// AirQuality is the parsed response of the Air Quality API. All requested hourly metrics
+// are available in HourlyMetrics, the common ones are also exposed as typed series in Hourly
+type AirQuality struct {
+	Latitude       float64
+	Longitude      float64
+	Elevation      float64
+	GenerationTime float64
+	Current        AirQualityData
+	CurrentUnits   map[string]string
+	HourlyUnits    map[string]string
+	HourlyMetrics  map[string][]float64
+	HourlyTimes    []time.Time
+	Hourly         AirQualityHourlyData
+	Stale          bool // Served from the cache after expiry, see StalePolicy
+}
 
-	body, err := c.Get(ctx, loc, opts)
+// GetAirQuality retrieves the air quality forecast for the provided location.
+//
+// When no metrics are requested, the current PM10, PM2.5, ozone, nitrogen dioxide and the
+// European and US AQI are returned, together with the hourly PM10 and PM2.5 series
+func (c Client) GetAirQuality(ctx context.Context, loc Location, opts *AirQualityOptions) (*AirQuality, error) {
+	opts = withAirQualityDefaults(opts)
+
+	body, stale, err := c.send(ctx, request{Endpoint: AirQualityEndpoint, Query: queryFromAirQualityOptions(opts, loc), TTL: opts.CacheTTL})
 	if err != nil {
-		return AirQualityData{}, fmt.Errorf("failed to get air quality data: %w", err)
+		return nil, fmt.Errorf("failed to get air quality data: %w", err)
 	}
 
-	aqData, err := ParseAirQualityData(body)
+	aq, err := ParseAirQualityData(body)
 	if err != nil {
-		return AirQualityData{}, fmt.Errorf("failed to parse air quality data: %w", err)
+		return nil, fmt.Errorf("failed to parse air quality data: %w", err)
+	}
+
+	aq.Stale = stale
+
+	return aq, nil
+}
+
+// GetAirQualityMany retrieves the air quality forecasts for multiple locations in as few API calls
+// as possible, see MaxBatchLocations. The forecasts are returned in input order. When one of the
+// calls fails, the forecasts fetched so far are returned along with the error and the rest are nil
+func (c Client) GetAirQualityMany(ctx context.Context, locs []Location, opts *AirQualityOptions) ([]*AirQuality, error) {
+	opts = withAirQualityDefaults(opts)
+
+	results, stale, fetchErr := c.sendMany(ctx, AirQualityEndpoint, locs, opts.CacheTTL, func(chunk []Location) url.Values {
+		return queryFromAirQualityOptions(opts, chunk...)
+	})
+	if fetchErr != nil {
+		fetchErr = fmt.Errorf("failed to get air quality data: %w", fetchErr)
+	}
+	if len(results) == 0 {
+		return nil, fetchErr
 	}
 
-	return aqData, nil
+	aqs := make([]*AirQuality, len(locs))
+	for i, body := range results {
+		aq, err := ParseAirQualityData(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse air quality data: %w", err)
+		}
+		aq.Stale = stale[i]
+		aqs[i] = aq
+	}
+
+	return aqs, fetchErr
 }
 
-func ParseAirQualityData(body []byte) (AirQualityData, error) {
+// withAirQualityDefaults returns a copy of opts requesting the default metrics of GetAirQuality
+// when none are set. The caller's options are left untouched
+func withAirQualityDefaults(opts *AirQualityOptions) *AirQualityOptions {
+	reqOpts := AirQualityOptions{}
+	if opts != nil {
+		reqOpts = *opts
+	}
+	if len(reqOpts.HourlyMetrics) == 0 && len(reqOpts.CurrentMetrics) == 0 {
+		reqOpts.HourlyMetrics = []string{"pm10", "pm2_5"}
+		reqOpts.CurrentMetrics = []string{"pm10", "pm2_5", "ozone", "nitrogen_dioxide", "european_aqi", "us_aqi"}
+	}
+	return &reqOpts
+}
+
+func queryFromAirQualityOptions(opts *AirQualityOptions, locs ...Location) url.Values {
+	q := url.Values{}
+	setLocations(q, locs...)
+
+	if len(opts.HourlyMetrics) > 0 {
+		q.Set("hourly", strings.Join(opts.HourlyMetrics, ","))
+	}
+	if len(opts.CurrentMetrics) > 0 {
+		q.Set("current", strings.Join(opts.CurrentMetrics, ","))
+	}
+	if opts.Domains != "" {
+		q.Set("domains", opts.Domains)
+	}
+	if opts.Timezone != "" {
+		q.Set("timezone", opts.Timezone)
+	}
+	if opts.PastDays != 0 {
+		q.Set("past_days", strconv.Itoa(opts.PastDays))
+	}
+	if opts.ForecastDays != 0 {
+		q.Set("forecast_days", strconv.Itoa(opts.ForecastDays))
+	}
+	if opts.StartDate != "" {
+		q.Set("start_date", opts.StartDate)
+	}
+	if opts.EndDate != "" {
+		q.Set("end_date", opts.EndDate)
+	}
+
+	return q
+}
+
+// ParseAirQualityData converts the Air Quality API response body into an AirQuality struct
+func ParseAirQualityData(body []byte) (*AirQuality, error) {
 	var data struct {
-		AirQuality AirQualityData `json:"air_quality"`
+		ForecastJSON
+		Current      AirQualityData    `json:"current"`
+		CurrentUnits map[string]string `json:"current_units"`
 	}
 
 	err := json.Unmarshal(body, &data)
 	if err != nil {
-		return AirQualityData{}, ErrAPIResponse{StatusCode: 0, Message: "Failed to parse JSON response"}
+		return nil, ErrAPIResponse{StatusCode: 0, Message: "Failed to parse JSON response"}
+	}
+
+	aq := &AirQuality{
+		Latitude:       data.Latitude,
+		Longitude:      data.Longitude,
+		Elevation:      data.Elevation,
+		GenerationTime: data.GenerationTime,
+		Current:        data.Current,
+		CurrentUnits:   data.CurrentUnits,
+		HourlyUnits:    data.HourlyUnits,
+	}
+
+	loc := data.location()
+	aq.Current.Time.Time = inLocation(aq.Current.Time.Time, loc)
+
+	aq.HourlyTimes, aq.HourlyMetrics, err = parseHourlyMetrics(data.HourlyMetrics, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	h := aq.HourlyMetrics
+	aq.Hourly = AirQualityHourlyData{
+		Time:           aq.HourlyTimes,
+		PM10:           h["pm10"],
+		PM2_5:          h["pm2_5"],
+		CarbonMonoxide: h["carbon_monoxide"],
+		NO2:            h["nitrogen_dioxide"],
+		SulphurDioxide: h["sulphur_dioxide"],
+		O3:             h["ozone"],
+		Dust:           h["dust"],
+		UVIndex:        h["uv_index"],
+		AlderPollen:    h["alder_pollen"],
+		BirchPollen:    h["birch_pollen"],
+		GrassPollen:    h["grass_pollen"],
+		MugwortPollen:  h["mugwort_pollen"],
+		OlivePollen:    h["olive_pollen"],
+		RagweedPollen:  h["ragweed_pollen"],
+		EuropeanAQI:    h["european_aqi"],
+		USAQI:          h["us_aqi"],
 	}
 
-	return data.AirQuality, nil
+	return aq, nil
 }
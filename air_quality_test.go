@@ -2,7 +2,11 @@ package omgo_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/jdotcurs/omgo"
 	"github.com/stretchr/testify/require"
@@ -15,18 +19,23 @@ func TestGetAirQuality(t *testing.T) {
 	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
 	require.NoError(t, err)
 
-	opts := &omgo.Options{
-		AirQualityMetrics: []string{"pm10", "pm2_5", "o3", "no2"},
+	opts := &omgo.AirQualityOptions{
+		HourlyMetrics:  []string{"pm10", "pm2_5", "ozone", "nitrogen_dioxide", "birch_pollen"},
+		CurrentMetrics: []string{"pm10", "pm2_5", "european_aqi"},
+		Domains:        omgo.AirQualityDomainCAMSEurope,
 	}
 
 	aqData, err := c.GetAirQuality(context.Background(), loc, opts)
 	require.NoError(t, err)
 
-	require.IsType(t, omgo.AirQualityData{}, aqData)
-	require.GreaterOrEqual(t, aqData.PM10, float64(0))
-	require.GreaterOrEqual(t, aqData.PM2_5, float64(0))
-	require.GreaterOrEqual(t, aqData.O3, float64(0))
-	require.GreaterOrEqual(t, aqData.NO2, float64(0))
+	require.NotEmpty(t, aqData.HourlyTimes)
+	require.Len(t, aqData.Hourly.PM10, len(aqData.HourlyTimes))
+	require.Len(t, aqData.Hourly.O3, len(aqData.HourlyTimes))
+	require.Len(t, aqData.Hourly.BirchPollen, len(aqData.HourlyTimes))
+	require.True(t, aqData.Current.Time.IsSet())
+	require.GreaterOrEqual(t, aqData.Current.PM10, float64(0))
+	require.GreaterOrEqual(t, aqData.Current.PM2_5, float64(0))
+	require.GreaterOrEqual(t, aqData.Current.EuropeanAQI, float64(0))
 }
 
 func TestGetAirQuality_InvalidLocation(t *testing.T) {
@@ -36,8 +45,8 @@ func TestGetAirQuality_InvalidLocation(t *testing.T) {
 	loc, err := omgo.NewLocation(1000, 1000) // Invalid location
 	require.NoError(t, err)
 
-	opts := &omgo.Options{
-		AirQualityMetrics: []string{"pm10", "pm2_5", "o3", "no2"},
+	opts := &omgo.AirQualityOptions{
+		HourlyMetrics: []string{"pm10", "pm2_5", "ozone", "nitrogen_dioxide"},
 	}
 
 	_, err = c.GetAirQuality(context.Background(), loc, opts)
@@ -53,7 +62,33 @@ func TestGetAirQuality_NilOptions(t *testing.T) {
 
 	aqData, err := c.GetAirQuality(context.Background(), loc, nil)
 	require.NoError(t, err)
-	require.IsType(t, omgo.AirQualityData{}, aqData)
+	require.NotEmpty(t, aqData.Hourly.PM2_5)
+	require.True(t, aqData.Current.Time.IsSet())
+}
+
+func TestParseAirQualityData(t *testing.T) {
+	body := []byte(`{
+		"latitude": 52.4,
+		"longitude": 4.9,
+		"current_units": {"time": "iso8601", "interval": "seconds", "pm2_5": "μg/m³", "us_aqi": "USAQI"},
+		"current": {"time": "2024-09-10T12:00", "interval": 3600, "pm2_5": 7.4, "us_aqi": 31},
+		"hourly_units": {"time": "iso8601", "pm10": "μg/m³"},
+		"hourly": {
+			"time": ["2024-09-10T00:00", "2024-09-10T01:00"],
+			"pm10": [11.2, 10.8],
+			"grass_pollen": [0.3, 0.1]
+		}
+	}`)
+
+	aq, err := omgo.ParseAirQualityData(body)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.September, 10, 12, 0, 0, 0, time.UTC), aq.Current.Time.Time)
+	require.Equal(t, 7.4, aq.Current.PM2_5)
+	require.Equal(t, float64(31), aq.Current.USAQI)
+	require.Equal(t, "USAQI", aq.CurrentUnits["us_aqi"])
+	require.Equal(t, []float64{11.2, 10.8}, aq.Hourly.PM10)
+	require.Equal(t, []float64{0.3, 0.1}, aq.Hourly.GrassPollen)
+	require.Len(t, aq.Hourly.Time, 2)
 }
 
 func TestParseAirQualityData_InvalidJSON(t *testing.T) {
@@ -62,3 +97,56 @@ func TestParseAirQualityData_InvalidJSON(t *testing.T) {
 	require.Error(t, err)
 	require.IsType(t, omgo.ErrAPIResponse{}, err)
 }
+
+func TestGetAirQualityMany(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`[
+			{"latitude": 52.38, "current": {"time": "2024-01-01T00:00", "pm2_5": 10.5}},
+			{"latitude": 48.86, "current": {"time": "2024-01-01T00:00", "pm2_5": 12.25}}
+		]`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.AirQualityEndpoint, srv.URL)
+
+	amsterdam, err := omgo.NewLocation(52.3738, 4.8910)
+	require.NoError(t, err)
+	paris, err := omgo.NewLocation(48.8566, 2.3522)
+	require.NoError(t, err)
+
+	aqs, err := c.GetAirQualityMany(context.Background(), []omgo.Location{amsterdam, paris}, &omgo.AirQualityOptions{CurrentMetrics: []string{"pm2_5"}})
+	require.NoError(t, err)
+	require.Equal(t, "52.3738,48.8566", query.Get("latitude"))
+	require.Equal(t, "4.891,2.3522", query.Get("longitude"))
+	require.Len(t, aqs, 2)
+	require.Equal(t, 10.5, aqs[0].Current.PM2_5)
+	require.Equal(t, 12.25, aqs[1].Current.PM2_5)
+}
+
+func TestGetAirQuality_OptionsUntouched(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`[{"latitude": 52.38}, {"latitude": 48.86}]`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.AirQualityEndpoint, srv.URL)
+
+	amsterdam, err := omgo.NewLocation(52.3738, 4.8910)
+	require.NoError(t, err)
+	paris, err := omgo.NewLocation(48.8566, 2.3522)
+	require.NoError(t, err)
+
+	opts := &omgo.AirQualityOptions{Domains: omgo.AirQualityDomainCAMSEurope}
+	_, err = c.GetAirQualityMany(context.Background(), []omgo.Location{amsterdam, paris}, opts)
+	require.NoError(t, err)
+	require.Equal(t, "pm10,pm2_5", query.Get("hourly"))
+	require.Equal(t, &omgo.AirQualityOptions{Domains: omgo.AirQualityDomainCAMSEurope}, opts)
+}
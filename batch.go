@@ -0,0 +1,123 @@
+package omgo
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBatchWorkers is the number of concurrent requests of a batch when none is given
+const DefaultBatchWorkers = 4
+
+// BatchJob is a single request of a batch, each job can use its own options
+type BatchJob struct {
+	Location Location
+	Options  *Options
+	Endpoint Endpoint // Defaults to ForecastEndpoint, see batchEndpoints for the accepted ones
+}
+
+// batchEndpoints are the API families answering in the shape of Forecast, other endpoints are
+// rejected with ErrInvalidInput
+var batchEndpoints = map[Endpoint]bool{
+	ForecastEndpoint:           true,
+	HistoricalForecastEndpoint: true,
+	ArchiveEndpoint:            true,
+	SeasonalEndpoint:           true,
+	PreviousRunsEndpoint:       true,
+}
+
+// BatchResult is the outcome of a single BatchJob. Err is set when the job failed, the other
+// jobs of the batch are not affected
+type BatchResult struct {
+	Index    int // Position of the job in the input, in the order jobs were received for channels
+	Job      BatchJob
+	Forecast *Forecast
+	Err      error
+}
+
+// Batch runs the jobs received on jobs over a pool of workers, DefaultBatchWorkers when
+// workers <= 0. All requests share the Client's RateLimiter, Quota and cache.
+//
+// Every job yields exactly one result on the returned channel, in completion order. The channel
+// is closed once jobs is closed and all jobs are done, it must be drained to release the workers.
+// After ctx is cancelled the remaining jobs fail with the context's error
+func (c Client) Batch(ctx context.Context, jobs <-chan BatchJob, workers int) <-chan BatchResult {
+	if workers <= 0 {
+		workers = DefaultBatchWorkers
+	}
+
+	type indexedJob struct {
+		index int
+		job   BatchJob
+	}
+
+	queue := make(chan indexedJob)
+	go func() {
+		i := 0
+		for job := range jobs {
+			queue <- indexedJob{index: i, job: job}
+			i++
+		}
+		close(queue)
+	}()
+
+	results := make(chan BatchResult)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for j := range queue {
+				results <- c.runBatchJob(ctx, j.index, j.job)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// BatchSlice is Batch for a fixed list of jobs, BatchResult.Index refers to the position in jobs
+func (c Client) BatchSlice(ctx context.Context, jobs []BatchJob, workers int) <-chan BatchResult {
+	ch := make(chan BatchJob)
+	go func() {
+		for _, job := range jobs {
+			ch <- job
+		}
+		close(ch)
+	}()
+
+	return c.Batch(ctx, ch, workers)
+}
+
+func (c Client) runBatchJob(ctx context.Context, index int, job BatchJob) BatchResult {
+	res := BatchResult{Index: index, Job: job}
+	if err := ctx.Err(); err != nil {
+		res.Err = err
+		return res
+	}
+
+	e := job.Endpoint
+	if e == "" {
+		e = ForecastEndpoint
+	}
+	if !batchEndpoints[e] {
+		res.Err = ErrInvalidInput{Param: "endpoint", Value: e}
+		return res
+	}
+
+	body, stale, err := c.get(ctx, e, job.Location, job.Options)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+
+	res.Forecast, res.Err = ParseBody(body)
+	if res.Forecast != nil {
+		res.Forecast.Stale = stale
+	}
+	return res
+}
@@ -0,0 +1,145 @@
+package omgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestBatchSlice(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"latitude": ` + r.URL.Query().Get("latitude") + `}`))
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+	c.RateLimiter = rate.NewLimiter(rate.Inf, 1)
+
+	jobs := make([]omgo.BatchJob, 10)
+	for i := range jobs {
+		loc, err := omgo.NewLocation(float64(i), 0)
+		require.NoError(t, err)
+		jobs[i] = omgo.BatchJob{Location: loc, Options: &omgo.Options{PastDays: i}}
+	}
+	jobs[3].Options = &omgo.Options{HourlyMetrics: []string{"not_a_metric"}}
+
+	seen := make(map[int]bool)
+	for res := range c.BatchSlice(context.Background(), jobs, 3) {
+		seen[res.Index] = true
+		if res.Index == 3 {
+			require.True(t, errors.Is(res.Err, omgo.ErrInvalidParameter))
+			continue
+		}
+		require.NoError(t, res.Err)
+		require.Equal(t, float64(res.Index), res.Forecast.Latitude)
+		require.Equal(t, jobs[res.Index], res.Job)
+	}
+
+	require.Len(t, seen, len(jobs))
+	require.LessOrEqual(t, maxInFlight, 3)
+	require.Greater(t, maxInFlight, 1)
+}
+
+func TestBatchEndpoints(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"latitude": 1}`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.HistoricalForecastEndpoint, srv.URL)
+	c.SetEndpoint(omgo.MarineEndpoint, srv.URL)
+
+	loc, err := omgo.NewLocation(1, 0)
+	require.NoError(t, err)
+	jobs := []omgo.BatchJob{
+		{Location: loc, Endpoint: omgo.HistoricalForecastEndpoint},
+		{Location: loc, Endpoint: omgo.MarineEndpoint},
+	}
+
+	results := make([]omgo.BatchResult, len(jobs))
+	for res := range c.BatchSlice(context.Background(), jobs, 1) {
+		results[res.Index] = res
+	}
+
+	require.NoError(t, results[0].Err)
+	require.Equal(t, 1.0, results[0].Forecast.Latitude)
+	require.Equal(t, omgo.ErrInvalidInput{Param: "endpoint", Value: omgo.MarineEndpoint}, results[1].Err)
+	require.Nil(t, results[1].Forecast)
+	require.Equal(t, 1, calls)
+}
+
+func TestBatchRespectsRateLimiter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+	c.RateLimiter = rate.NewLimiter(rate.Every(50*time.Millisecond), 1)
+
+	jobs := make(chan omgo.BatchJob)
+	go func() {
+		for i := 0; i < 5; i++ {
+			loc, _ := omgo.NewLocation(float64(i), 0)
+			jobs <- omgo.BatchJob{Location: loc}
+		}
+		close(jobs)
+	}()
+
+	start := time.Now()
+	n := 0
+	for res := range c.Batch(context.Background(), jobs, 5) {
+		require.NoError(t, res.Err)
+		n++
+	}
+	require.Equal(t, 5, n)
+	require.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestBatchCancelled(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	n := 0
+	for res := range c.BatchSlice(ctx, []omgo.BatchJob{{Location: loc}, {Location: loc}}, 0) {
+		require.ErrorIs(t, res.Err, context.Canceled)
+		n++
+	}
+	require.Equal(t, 2, n)
+}
@@ -1,45 +1,254 @@
 package omgo
 
 import (
+	"container/list"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
+// Cache stores API response bodies by request key. Implementations must be safe for
+// concurrent use. Set the Client.Cache field to plug in a custom store
+type Cache interface {
+	// Get returns the data stored under key, if present and not expired
+	Get(key string) ([]byte, bool)
+	// Set stores data under key for the given time to live
+	Set(key string, data []byte, ttl time.Duration)
+	// Delete removes key from the cache
+	Delete(key string)
+}
+
+// Clearer is implemented by caches that can drop all their entries at once
+type Clearer interface {
+	Clear()
+}
+
 type cacheItem struct {
+	key        string
 	data       []byte
 	expiration time.Time
 }
 
-type Cache struct {
-	items map[string]cacheItem
-	mu    sync.RWMutex
+func (i *cacheItem) size() int64 {
+	return int64(len(i.key) + len(i.data))
+}
+
+// MemoryCache is the default, in-memory Cache. By default it is unbounded, use the
+// CacheOptions of NewCache to evict the least recently used entries beyond a number of
+// entries or bytes, and to sweep expired entries in the background
+type MemoryCache struct {
+	items      map[string]*list.Element // Values are *cacheItem
+	lru        *list.List               // Most recently used entries at the front
+	bytes      int64
+	maxEntries int
+	maxBytes   int64
+	mu         sync.Mutex
+
+	janitorInterval time.Duration
+	stop            chan struct{}
+	stopOnce        sync.Once
+}
+
+// CacheOption configures a MemoryCache created by NewCache
+type CacheOption func(*MemoryCache)
+
+// WithMaxEntries bounds the cache to n entries, evicting the least recently used ones
+func WithMaxEntries(n int) CacheOption {
+	return func(c *MemoryCache) {
+		c.maxEntries = n
+	}
+}
+
+// WithMaxBytes bounds the total size of the cached keys and data to n bytes, evicting the
+// least recently used entries. Entries larger than n are not cached
+func WithMaxBytes(n int64) CacheOption {
+	return func(c *MemoryCache) {
+		c.maxBytes = n
+	}
+}
+
+// WithJanitor starts a background goroutine removing expired entries every interval.
+// Call Close to stop it
+func WithJanitor(interval time.Duration) CacheOption {
+	return func(c *MemoryCache) {
+		c.janitorInterval = interval
+	}
 }
 
-func NewCache() *Cache {
-	return &Cache{
-		items: make(map[string]cacheItem),
+func NewCache(opts ...CacheOption) *MemoryCache {
+	c := &MemoryCache{
+		items: make(map[string]*list.Element),
+		lru:   list.New(),
+		stop:  make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.janitorInterval > 0 {
+		go c.janitor()
+	}
+
+	return c
 }
 
-func (c *Cache) Set(key string, data []byte, expiration time.Duration) {
+func (c *MemoryCache) Set(key string, data []byte, expiration time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.items[key] = cacheItem{
+
+	item := &cacheItem{
+		key:        key,
 		data:       data,
 		expiration: time.Now().Add(expiration),
 	}
+	if c.maxBytes > 0 && item.size() > c.maxBytes {
+		c.remove(key)
+		return
+	}
+
+	if el, found := c.items[key]; found {
+		c.bytes += item.size() - el.Value.(*cacheItem).size()
+		el.Value = item
+		c.lru.MoveToFront(el)
+	} else {
+		c.items[key] = c.lru.PushFront(item)
+		c.bytes += item.size()
+	}
+
+	for (c.maxEntries > 0 && c.lru.Len() > c.maxEntries) || (c.maxBytes > 0 && c.bytes > c.maxBytes) {
+		c.remove(c.lru.Back().Value.(*cacheItem).key)
+	}
 }
 
-func (c *Cache) Get(key string) ([]byte, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	item, found := c.items[key]
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	// A full lock is required, as reading updates the LRU order and drops expired entries
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, found := c.items[key]
 	if !found {
 		return nil, false
 	}
+	item := el.Value.(*cacheItem)
 	if time.Now().After(item.expiration) {
-		delete(c.items, key)
+		c.remove(key)
 		return nil, false
 	}
+	c.lru.MoveToFront(el)
 	return item.data, true
 }
+
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.remove(key)
+}
+
+func (c *MemoryCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[string]*list.Element)
+	c.lru.Init()
+	c.bytes = 0
+}
+
+// Len returns the number of entries in the cache, including expired entries not yet removed
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Close stops the janitor goroutine, if any. The cache remains usable afterwards
+func (c *MemoryCache) Close() error {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+	return nil
+}
+
+// remove deletes key, the caller must hold the lock
+func (c *MemoryCache) remove(key string) {
+	el, found := c.items[key]
+	if !found {
+		return
+	}
+	c.bytes -= el.Value.(*cacheItem).size()
+	c.lru.Remove(el)
+	delete(c.items, key)
+}
+
+// deleteExpired removes all expired entries
+func (c *MemoryCache) deleteExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for key, el := range c.items {
+		if now.After(el.Value.(*cacheItem).expiration) {
+			c.remove(key)
+		}
+	}
+}
+
+func (c *MemoryCache) janitor() {
+	ticker := time.NewTicker(c.janitorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.deleteExpired()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// NoopCache is a Cache that never stores anything, use it to disable caching
+type NoopCache struct{}
+
+func (NoopCache) Get(key string) ([]byte, bool)                  { return nil, false }
+func (NoopCache) Set(key string, data []byte, ttl time.Duration) {}
+func (NoopCache) Delete(key string)                              {}
+func (NoopCache) Clear()                                         {}
+
+// cacheKeyPrecision is the number of decimals coordinates are rounded to in cache keys,
+// 4 decimals is about 11 meters, well below the resolution of any weather model
+const cacheKeyPrecision = 4
+
+// cacheKey builds the cache key of a request URL: the endpoint followed by the sorted query
+// parameters, with rounded coordinates and without credentials
+func cacheKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	q := u.Query()
+	q.Del("apikey")
+	for _, param := range []string{"latitude", "longitude"} {
+		if v := q.Get(param); v != "" {
+			q.Set(param, roundCoordinates(v))
+		}
+	}
+
+	u.RawQuery = q.Encode()
+	u.User = nil
+	u.Fragment = ""
+	return u.String()
+}
+
+// roundCoordinates rounds a comma separated list of coordinates to cacheKeyPrecision decimals
+func roundCoordinates(list string) string {
+	parts := strings.Split(list, ",")
+	scale := math.Pow10(cacheKeyPrecision)
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			continue
+		}
+		parts[i] = strconv.FormatFloat(math.Round(f*scale)/scale, 'f', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
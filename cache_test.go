@@ -62,3 +62,130 @@ func TestCacheConcurrency(t *testing.T) {
 	require.True(t, found)
 	require.Equal(t, value, retrievedValue)
 }
+
+func TestCacheDelete(t *testing.T) {
+	cache := omgo.NewCache()
+	cache.Set("testKey", []byte("testValue"), time.Minute)
+	cache.Delete("testKey")
+
+	_, found := cache.Get("testKey")
+	require.False(t, found)
+}
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := omgo.NewFileCache(dir)
+	require.NoError(t, err)
+
+	key := "https://api.open-meteo.com/v1/forecast?latitude=52.37&longitude=4.89"
+	value := []byte(`{"latitude": 52.37}`)
+	cache.Set(key, value, time.Minute)
+
+	retrievedValue, found := cache.Get(key)
+	require.True(t, found)
+	require.Equal(t, value, retrievedValue)
+
+	// A new cache on the same directory, e.g. after a restart, sees the entry
+	reopened, err := omgo.NewFileCache(dir)
+	require.NoError(t, err)
+	retrievedValue, found = reopened.Get(key)
+	require.True(t, found)
+	require.Equal(t, value, retrievedValue)
+
+	reopened.Delete(key)
+	_, found = cache.Get(key)
+	require.False(t, found)
+}
+
+func TestFileCacheExpiration(t *testing.T) {
+	cache, err := omgo.NewFileCache(t.TempDir())
+	require.NoError(t, err)
+
+	cache.Set("testKey", []byte("testValue"), -time.Second)
+
+	_, found := cache.Get("testKey")
+	require.False(t, found)
+}
+
+func TestFileCacheClear(t *testing.T) {
+	cache, err := omgo.NewFileCache(t.TempDir())
+	require.NoError(t, err)
+
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), time.Minute)
+	cache.Clear()
+
+	_, found := cache.Get("a")
+	require.False(t, found)
+	_, found = cache.Get("b")
+	require.False(t, found)
+}
+
+func TestNoopCache(t *testing.T) {
+	var cache omgo.Cache = omgo.NoopCache{}
+	cache.Set("testKey", []byte("testValue"), time.Minute)
+
+	_, found := cache.Get("testKey")
+	require.False(t, found)
+}
+
+func TestClientClearCache(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	c.Cache.Set("testKey", []byte("testValue"), time.Minute)
+	c.ClearCache()
+
+	_, found := c.Cache.Get("testKey")
+	require.False(t, found)
+}
+
+func TestCacheMaxEntries(t *testing.T) {
+	cache := omgo.NewCache(omgo.WithMaxEntries(2))
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), time.Minute)
+
+	// Reading "a" makes "b" the least recently used entry
+	_, found := cache.Get("a")
+	require.True(t, found)
+
+	cache.Set("c", []byte("3"), time.Minute)
+	require.Equal(t, 2, cache.Len())
+
+	_, found = cache.Get("b")
+	require.False(t, found)
+	_, found = cache.Get("a")
+	require.True(t, found)
+	_, found = cache.Get("c")
+	require.True(t, found)
+}
+
+func TestCacheMaxBytes(t *testing.T) {
+	cache := omgo.NewCache(omgo.WithMaxBytes(10))
+	cache.Set("a", []byte("1234"), time.Minute) // 5 bytes
+	cache.Set("b", []byte("1234"), time.Minute) // 5 bytes
+	cache.Set("c", []byte("12"), time.Minute)   // 3 bytes, evicts "a"
+
+	_, found := cache.Get("a")
+	require.False(t, found)
+	_, found = cache.Get("b")
+	require.True(t, found)
+
+	// Entries larger than the bound are not stored
+	cache.Set("d", []byte("0123456789"), time.Minute)
+	_, found = cache.Get("d")
+	require.False(t, found)
+}
+
+func TestCacheJanitor(t *testing.T) {
+	cache := omgo.NewCache(omgo.WithJanitor(10 * time.Millisecond))
+	defer cache.Close()
+
+	cache.Set("a", []byte("1"), time.Millisecond)
+	cache.Set("b", []byte("2"), time.Minute)
+
+	require.Eventually(t, func() bool { return cache.Len() == 1 }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, cache.Close())
+	require.NoError(t, cache.Close())
+}
@@ -2,41 +2,157 @@ package omgo
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/time/rate"
 )
 
 type Client struct {
-	URL         string
+	Endpoints   map[Endpoint]string // Base URL per API family, see DefaultEndpoints. Shared by copies, use SetEndpoint
 	UserAgent   string
 	Client      *http.Client
 	APIKey      string
 	LastRequest time.Time
 	RateLimiter *rate.Limiter
-	Cache       *Cache
+	Cache       Cache       // Defaults to an in-memory cache, nil disables caching
+	Retry       RetryPolicy // The zero value disables retries
+	TTL         TTLPolicy   // How long responses are cached per endpoint
+	Stale       StalePolicy // Whether expired responses may still be served, disabled by default
+	Quota       *Quota      // Call weight spent against the API limits, nil (the default) disables tracking
+
+	revalidating *sync.Map    // URLs being refreshed in the background, see StalePolicy
+	flights      *flightGroup // Requests in flight, shared between concurrent callers
 }
 
 const DefaultUserAgent = "Open-Meteo_Go_Client"
 const MinRequestInterval = time.Second / 10 // 10 requests per second
 
+// Endpoint identifies an Open-Meteo API family. Each family is served from its own host.
+type Endpoint string
+
+const (
+	ForecastEndpoint   Endpoint = "forecast"
+	ArchiveEndpoint    Endpoint = "archive"
+	AirQualityEndpoint Endpoint = "air_quality"
+	SeasonalEndpoint   Endpoint = "seasonal"
+	SatelliteEndpoint  Endpoint = "satellite"
+	MarineEndpoint     Endpoint = "marine"
+	FloodEndpoint      Endpoint = "flood"
+	ClimateEndpoint    Endpoint = "climate"
+	EnsembleEndpoint   Endpoint = "ensemble"
+	GeocodingEndpoint  Endpoint = "geocoding"
+	ElevationEndpoint  Endpoint = "elevation"
+
+	HistoricalForecastEndpoint Endpoint = "historical_forecast"
+	PreviousRunsEndpoint       Endpoint = "previous_runs"
+)
+
+// DefaultEndpoints lists the public Open-Meteo base URL for every API family
+var DefaultEndpoints = map[Endpoint]string{
+	ForecastEndpoint:   "https://api.open-meteo.com/v1/forecast",
+	ArchiveEndpoint:    "https://archive-api.open-meteo.com/v1/archive",
+	AirQualityEndpoint: "https://air-quality-api.open-meteo.com/v1/air-quality",
+	SeasonalEndpoint:   "https://seasonal-api.open-meteo.com/v1/seasonal",
+	SatelliteEndpoint:  "https://satellite-api.open-meteo.com/v1/archive",
+	MarineEndpoint:     "https://marine-api.open-meteo.com/v1/marine",
+	FloodEndpoint:      "https://flood-api.open-meteo.com/v1/flood",
+	ClimateEndpoint:    "https://climate-api.open-meteo.com/v1/climate",
+	EnsembleEndpoint:   "https://ensemble-api.open-meteo.com/v1/ensemble",
+	GeocodingEndpoint:  "https://geocoding-api.open-meteo.com/v1/search",
+	ElevationEndpoint:  "https://api.open-meteo.com/v1/elevation",
+
+	HistoricalForecastEndpoint: "https://historical-forecast-api.open-meteo.com/v1/forecast",
+	PreviousRunsEndpoint:       "https://previous-runs-api.open-meteo.com/v1/forecast",
+}
+
 func NewClient() (Client, error) {
+	endpoints := make(map[Endpoint]string, len(DefaultEndpoints))
+	for e, u := range DefaultEndpoints {
+		endpoints[e] = u
+	}
+
 	return Client{
-		URL:         "https://api.open-meteo.com/v1/forecast",
+		Endpoints:   endpoints,
 		UserAgent:   DefaultUserAgent,
 		Client:      http.DefaultClient,
 		RateLimiter: rate.NewLimiter(rate.Every(time.Second/10), 1), // 10 requests per second
 		Cache:       NewCache(),
+		Retry:       DefaultRetryPolicy(),
+		TTL:         DefaultTTLPolicy(),
+
+		revalidating: &sync.Map{},
+		flights:      newFlightGroup(),
 	}, nil
 }
 
-// Add a method to set the API key
+// customerHostPrefix is prepended to the Open-Meteo hosts of the commercial API,
+// e.g. customer-api.open-meteo.com
+const customerHostPrefix = "customer-"
+
+// SetAPIKey sets the key of a commercial Open-Meteo plan and switches every endpoint on an
+// open-meteo.com host to its customer- prefixed host. Clearing the key switches back to the
+// public hosts. Endpoints pointing elsewhere, e.g. a self-hosted instance, are left untouched
 func (c *Client) SetAPIKey(key string) {
 	c.APIKey = key
+
+	endpoints := c.cloneEndpoints()
+	for e := range DefaultEndpoints {
+		endpoints[e] = ""
+	}
+	for e := range endpoints {
+		endpoints[e] = customerURL(c.EndpointURL(e), key != "")
+	}
+	c.Endpoints = endpoints
+}
+
+// customerURL adds or removes the customer- prefix on the host of an Open-Meteo URL
+func customerURL(rawURL string, customer bool) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || !strings.HasSuffix(u.Host, ".open-meteo.com") {
+		return rawURL
+	}
+
+	host := strings.TrimPrefix(u.Host, customerHostPrefix)
+	if customer {
+		host = customerHostPrefix + host
+	}
+	u.Host = host
+	return u.String()
+}
+
+// SetEndpoint overrides the base URL used for the given API family. Copies of the Client made
+// before the call keep their endpoints
+func (c *Client) SetEndpoint(e Endpoint, url string) {
+	endpoints := c.cloneEndpoints()
+	endpoints[e] = url
+	c.Endpoints = endpoints
+}
+
+// cloneEndpoints copies the endpoint overrides, the map is shared between copies of the Client
+// and must not be written in place
+func (c *Client) cloneEndpoints() map[Endpoint]string {
+	endpoints := make(map[Endpoint]string, len(c.Endpoints)+1)
+	for e, u := range c.Endpoints {
+		endpoints[e] = u
+	}
+	return endpoints
+}
+
+// EndpointURL returns the base URL used for the given API family, falling back
+// to DefaultEndpoints when no override is set
+func (c *Client) EndpointURL(e Endpoint) string {
+	if u, ok := c.Endpoints[e]; ok && u != "" {
+		return u
+	}
+	return DefaultEndpoints[e]
 }
 
 type Location struct {
@@ -48,128 +164,278 @@ func NewLocation(lat, lon float64) (Location, error) {
 }
 
 type Options struct {
-	TemperatureUnit   string   // Default "celsius"
-	WindspeedUnit     string   // Default "kmh",
-	PrecipitationUnit string   // Default "mm"
-	Timezone          string   // Default "UTC"
-	PastDays          int      // Default 0
-	HourlyMetrics     []string // Lists required hourly metrics, see https://open-meteo.com/en/docs for valid metrics
-	DailyMetrics      []string // Lists required daily metrics, see https://open-meteo.com/en/docs for valid metrics
-	AirQualityMetrics []string // List of required air quality metrics
-	SatelliteMetrics  []string // List of required satellite metrics
-	StartDate         string   // Start date for historical data (format: YYYY-MM-DD)
-	EndDate           string   // End date for historical data (format: YYYY-MM-DD)
-	SeasonalForecast  bool     // Enable seasonal forecast
-	ForecastMonths    int      // Number of months to forecast (1-6)
+	TemperatureUnit   string        // Default "celsius"
+	WindspeedUnit     string        // Default "kmh",
+	PrecipitationUnit string        // Default "mm"
+	Timezone          string        // Default "UTC", TimezoneAuto for the timezone of the location
+	PastDays          int           // Default 0
+	HourlyMetrics     []string      // Lists required hourly metrics, see https://open-meteo.com/en/docs for valid metrics
+	DailyMetrics      []string      // Lists required daily metrics, see https://open-meteo.com/en/docs for valid metrics
+	CurrentMetrics    []string      // Lists required current conditions, see the Current constants
+	Minutely15Metrics []string      // Lists required 15-minutely metrics, see the Minutely15 constants
+	SatelliteMetrics  []string      // List of required satellite metrics
+	StartDate         string        // Start date for historical data (format: YYYY-MM-DD)
+	EndDate           string        // End date for historical data (format: YYYY-MM-DD)
+	SeasonalForecast  bool          // Enable seasonal forecast
+	ForecastMonths    int           // Number of months to forecast (1-6)
+	Elevation         *float64      // Overrides the terrain elevation (meters) used for statistical downscaling
+	CacheTTL          time.Duration // Overrides the Client's TTLPolicy for this request, negative disables caching
+
+	// AllowUnknownVariables sends variables unknown to this package as is, e.g. ones added to the
+	// API after this release. Units, days and dates are still validated
+	AllowUnknownVariables bool
 }
 
-func urlFromOptions(baseURL string, loc Location, opts *Options) string {
-	// TODO: Validate the Options are valid
-	url := fmt.Sprintf(`%s?latitude=%f&longitude=%f&current_weather=true`, baseURL, loc.lat, loc.lon)
-	if opts == nil {
-		return url
+// Query returns the query parameters sent for the options and the given locations, e.g. to
+// inspect or sign a request. Without locations only the parameters of the options are returned.
+// The API key is not included, it is only added when the request is sent
+func (o *Options) Query(locs ...Location) url.Values {
+	q := url.Values{}
+	setLocations(q, locs...)
+	q.Set("current_weather", "true")
+	if o == nil {
+		return q
 	}
 
-	if opts.TemperatureUnit != "" {
-		url = fmt.Sprintf(`%s&temperature_unit=%s`, url, opts.TemperatureUnit)
+	if o.TemperatureUnit != "" {
+		q.Set("temperature_unit", o.TemperatureUnit)
+	}
+	if o.WindspeedUnit != "" {
+		q.Set("windspeed_unit", o.WindspeedUnit)
+	}
+	if o.PrecipitationUnit != "" {
+		q.Set("precipitation_unit", o.PrecipitationUnit)
 	}
-	if opts.WindspeedUnit != "" {
-		url = fmt.Sprintf(`%s&windspeed_unit=%s`, url, opts.WindspeedUnit)
+	if o.Timezone != "" {
+		q.Set("timezone", o.Timezone)
 	}
-	if opts.PrecipitationUnit != "" {
-		url = fmt.Sprintf(`%s&precipitation_unit=%s`, url, opts.PrecipitationUnit)
+	if o.PastDays != 0 {
+		q.Set("past_days", strconv.Itoa(o.PastDays))
 	}
-	if opts.Timezone != "" {
-		url = fmt.Sprintf(`%s&timezone=%s`, url, opts.Timezone)
+	if o.Elevation != nil {
+		q.Set("elevation", formatFloat(*o.Elevation))
+	}
+
+	if len(o.HourlyMetrics) > 0 {
+		q.Set("hourly", strings.Join(o.HourlyMetrics, ","))
+	}
+	if len(o.DailyMetrics) > 0 {
+		q.Set("daily", strings.Join(o.DailyMetrics, ","))
+	}
+	if len(o.CurrentMetrics) > 0 {
+		q.Set("current", strings.Join(o.CurrentMetrics, ","))
+	}
+	if len(o.Minutely15Metrics) > 0 {
+		q.Set("minutely_15", strings.Join(o.Minutely15Metrics, ","))
+	}
+	if len(o.SatelliteMetrics) > 0 {
+		q.Set("satellite", strings.Join(o.SatelliteMetrics, ","))
+	}
+
+	if o.StartDate != "" {
+		q.Set("start_date", o.StartDate)
+	}
+	if o.EndDate != "" {
+		q.Set("end_date", o.EndDate)
+	}
+
+	if o.SeasonalForecast {
+		q.Set("seasonal", "true")
+		if o.ForecastMonths > 0 && o.ForecastMonths <= 6 {
+			q.Set("forecast_months", strconv.Itoa(o.ForecastMonths))
+		}
 	}
-	if opts.PastDays != 0 {
-		url = fmt.Sprintf(`%s&past_days=%d`, url, opts.PastDays)
+
+	return q
+}
+
+// ResponseMeta describes how a raw response body was obtained
+type ResponseMeta struct {
+	Stale bool // Served from the cache after expiry, see StalePolicy
+}
+
+// Get requests the forecast API for the provided location and returns the raw response body.
+// Use GetWithMeta to tell whether the body was served stale
+func (c *Client) Get(ctx context.Context, loc Location, opts *Options) ([]byte, error) {
+	return c.GetFrom(ctx, ForecastEndpoint, loc, opts)
+}
+
+// GetFrom requests the given API family for the provided location and returns the raw response body
+func (c *Client) GetFrom(ctx context.Context, e Endpoint, loc Location, opts *Options) ([]byte, error) {
+	body, _, err := c.GetWithMeta(ctx, e, loc, opts)
+	return body, err
+}
+
+// GetWithMeta is GetFrom, additionally returning how the body was obtained
+func (c *Client) GetWithMeta(ctx context.Context, e Endpoint, loc Location, opts *Options) ([]byte, ResponseMeta, error) {
+	body, stale, err := c.get(ctx, e, loc, opts)
+	return body, ResponseMeta{Stale: stale}, err
+}
+
+// get is GetFrom, additionally reporting whether the body was served stale from the cache.
+// Invalid options are rejected before any request is made
+func (c *Client) get(ctx context.Context, e Endpoint, loc Location, opts *Options) ([]byte, bool, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, false, err
 	}
 
-	if len(opts.HourlyMetrics) > 0 {
-		metrics := strings.Join(opts.HourlyMetrics, ",")
-		url = fmt.Sprintf(`%s&hourly=%s`, url, metrics)
+	r := request{Endpoint: e, Query: opts.Query(loc)}
+	if opts != nil {
+		r.TTL = opts.CacheTTL
 	}
+	return c.send(ctx, r)
+}
 
-	if len(opts.DailyMetrics) > 0 {
-		metrics := strings.Join(opts.DailyMetrics, ",")
-		url = fmt.Sprintf(`%s&daily=%s`, url, metrics)
+// getMany is get for multiple locations, returning the raw result per location
+func (c *Client) getMany(ctx context.Context, e Endpoint, locs []Location, opts *Options) ([]json.RawMessage, []bool, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, nil, err
 	}
 
-	if len(opts.AirQualityMetrics) > 0 {
-		metrics := strings.Join(opts.AirQualityMetrics, ",")
-		url = fmt.Sprintf(`%s&air_quality=%s`, url, metrics)
+	var ttl time.Duration
+	if opts != nil {
+		ttl = opts.CacheTTL
 	}
+	return c.sendMany(ctx, e, locs, ttl, func(chunk []Location) url.Values {
+		return opts.Query(chunk...)
+	})
+}
 
-	if len(opts.SatelliteMetrics) > 0 {
-		metrics := strings.Join(opts.SatelliteMetrics, ",")
-		url = fmt.Sprintf(`%s&satellite=%s`, url, metrics)
+// getURL performs the request against a fully built URL, sharing the cache and rate limiter
+// between all API families. Responses are cached for ttl, or following the TTLPolicy for the
+// endpoint when ttl is 0. The returned bool reports whether the body was served stale
+func (c *Client) getURL(ctx context.Context, e Endpoint, url string, ttl time.Duration) ([]byte, bool, error) {
+	// The API key is only added when sending the request, keeping it out of the cache keys
+	key := cacheKey(url)
+
+	if ttl == 0 {
+		ttl = c.TTL.For(e, time.Now())
+	}
+	if c.Cache == nil || ttl < 0 {
+		body, err := c.fetchShared(ctx, key, url, nil)
+		return body, false, err
 	}
 
-	if opts.StartDate != "" {
-		url = fmt.Sprintf(`%s&start_date=%s`, url, opts.StartDate)
+	if c.Stale.Grace > 0 {
+		return c.getStale(ctx, key, url, ttl)
 	}
-	if opts.EndDate != "" {
-		url = fmt.Sprintf(`%s&end_date=%s`, url, opts.EndDate)
+
+	// Check cache first
+	if cachedData, found := c.Cache.Get(key); found {
+		return cachedData, false, nil
 	}
 
-	if opts.SeasonalForecast {
-		url = fmt.Sprintf(`%s&seasonal=true`, url)
-		if opts.ForecastMonths > 0 && opts.ForecastMonths <= 6 {
-			url = fmt.Sprintf(`%s&forecast_months=%d`, url, opts.ForecastMonths)
+	body, err := c.fetchShared(ctx, key, url, func(body []byte) {
+		if ttl > 0 {
+			c.Cache.Set(key, body, ttl)
 		}
+	})
+	if err != nil {
+		return nil, false, err
 	}
 
-	return url
+	return body, false, nil
 }
 
-func (c *Client) Get(ctx context.Context, loc Location, opts *Options) ([]byte, error) {
-	url := urlFromOptions(c.URL, loc, opts)
-	if c.APIKey != "" {
-		url = fmt.Sprintf("%s&apikey=%s", url, c.APIKey)
+// fetchShared is fetch, sharing a single request between concurrent callers of the same cache key.
+// store is called once with the body of a successful request, before any caller returns
+func (c *Client) fetchShared(ctx context.Context, key string, url string, store func(body []byte)) ([]byte, error) {
+	fn := func(ctx context.Context) ([]byte, error) {
+		body, err := c.fetch(ctx, url)
+		if err == nil && store != nil {
+			store(body)
+		}
+		return body, err
 	}
 
-	// Check cache first
-	if cachedData, found := c.Cache.Get(url); found {
-		return cachedData, nil
+	if c.flights == nil {
+		return fn(ctx)
 	}
+	return c.flights.do(ctx, key, fn)
+}
+
+// fetch requests url from the API, retrying following the Client's RetryPolicy
+func (c *Client) fetch(ctx context.Context, url string) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		body, statusCode, retryAfter, err := c.do(ctx, url)
+		if err == nil {
+			return body, nil
+		}
+
+		if attempt >= c.Retry.MaxAttempts || ctx.Err() != nil || !c.Retry.retryable(statusCode, err) {
+			return nil, err
+		}
 
+		wait := retryAfter
+		if wait <= 0 {
+			wait = c.Retry.backoff(attempt)
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			// Waiting would exceed the deadline, report the last error instead of the timeout
+			return nil, err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// do performs a single request. The status code is 0 when no response was received, retryAfter
+// holds the delay requested through the Retry-After header, if any
+func (c *Client) do(ctx context.Context, rawURL string) (body []byte, statusCode int, retryAfter time.Duration, err error) {
 	if err := c.RateLimiter.Wait(ctx); err != nil {
-		return nil, ErrRateLimit{Message: "Rate limit exceeded"}
+		return nil, 0, 0, ErrRateLimit{Message: "Rate limit exceeded"}
+	}
+	if c.Quota != nil {
+		if err := c.Quota.Reserve(ctx, urlCallWeight(rawURL)); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	reqURL := rawURL
+	if c.APIKey != "" {
+		reqURL = fmt.Sprintf("%s&apikey=%s", rawURL, url.QueryEscape(c.APIKey))
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
 	if err != nil {
-		return nil, err
+		return nil, 0, 0, redactError(err)
 	}
 	req.Header.Set("User-Agent", c.UserAgent)
 
 	res, err := c.Client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, 0, 0, redactError(err)
 	}
 	defer res.Body.Close()
 
+	retryAfter = parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
+
 	if res.StatusCode == 429 {
-		return nil, ErrRateLimit{Message: "Rate limit exceeded"}
+		return nil, res.StatusCode, retryAfter, ErrRateLimit{Message: "Rate limit exceeded"}
 	}
 
 	if res.StatusCode != 200 {
 		body, _ := io.ReadAll(res.Body)
-		return nil, ErrAPIResponse{StatusCode: res.StatusCode, Message: string(body)}
+		return nil, res.StatusCode, retryAfter, parseErrorBody(res.StatusCode, rawURL, body)
 	}
 
-	body, err := io.ReadAll(res.Body)
+	body, err = io.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return nil, res.StatusCode, 0, err
 	}
 
-	// Cache the response for 5 minutes
-	c.Cache.Set(url, body, 5*time.Minute)
-
-	return body, nil
+	return body, res.StatusCode, 0, nil
 }
 
+// ClearCache drops all cached responses, if the cache implements Clearer
 func (c *Client) ClearCache() {
-	c.Cache = NewCache()
+	if cl, ok := c.Cache.(Clearer); ok {
+		cl.Clear()
+	}
 }
@@ -2,6 +2,10 @@ package omgo_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,6 +22,44 @@ func TestSetAPIKey(t *testing.T) {
 	require.Equal(t, apiKey, c.APIKey)
 }
 
+func TestSetAPIKeyCustomerHosts(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.GeocodingEndpoint, "http://localhost:8080/v1/search")
+
+	c.SetAPIKey("test_api_key")
+	require.Equal(t, "https://customer-api.open-meteo.com/v1/forecast", c.EndpointURL(omgo.ForecastEndpoint))
+	require.Equal(t, "https://customer-archive-api.open-meteo.com/v1/archive", c.EndpointURL(omgo.ArchiveEndpoint))
+	require.Equal(t, "https://customer-marine-api.open-meteo.com/v1/marine", c.EndpointURL(omgo.MarineEndpoint))
+	require.Equal(t, "http://localhost:8080/v1/search", c.EndpointURL(omgo.GeocodingEndpoint))
+
+	// Setting a key again does not stack prefixes
+	c.SetAPIKey("other_api_key")
+	require.Equal(t, "https://customer-api.open-meteo.com/v1/forecast", c.EndpointURL(omgo.ForecastEndpoint))
+
+	c.SetAPIKey("")
+	for e, u := range omgo.DefaultEndpoints {
+		if e != omgo.GeocodingEndpoint {
+			require.Equal(t, u, c.EndpointURL(e))
+		}
+	}
+}
+
+func TestSetAPIKeyLeavesCopiesUntouched(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.GeocodingEndpoint, "http://localhost:8080/v1/search")
+
+	customer := c
+	customer.SetAPIKey("test_api_key")
+	customer.SetEndpoint(omgo.ElevationEndpoint, "http://localhost:8080/v1/elevation")
+	require.Equal(t, "https://customer-api.open-meteo.com/v1/forecast", customer.EndpointURL(omgo.ForecastEndpoint))
+
+	require.Equal(t, omgo.DefaultEndpoints[omgo.ForecastEndpoint], c.EndpointURL(omgo.ForecastEndpoint))
+	require.Equal(t, omgo.DefaultEndpoints[omgo.ElevationEndpoint], c.EndpointURL(omgo.ElevationEndpoint))
+	require.Equal(t, "http://localhost:8080/v1/search", c.EndpointURL(omgo.GeocodingEndpoint))
+}
+
 func TestClientCaching(t *testing.T) {
 	client, err := omgo.NewClient()
 	require.NoError(t, err)
@@ -42,3 +84,152 @@ func TestClientCaching(t *testing.T) {
 	// The second request should be significantly faster due to caching
 	require.Less(t, duration, 10*time.Millisecond)
 }
+
+func TestEndpoints(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	require.Equal(t, "https://api.open-meteo.com/v1/forecast", c.EndpointURL(omgo.ForecastEndpoint))
+	require.Equal(t, "https://archive-api.open-meteo.com/v1/archive", c.EndpointURL(omgo.ArchiveEndpoint))
+
+	c.SetEndpoint(omgo.ArchiveEndpoint, "http://localhost/archive")
+	require.Equal(t, "http://localhost/archive", c.EndpointURL(omgo.ArchiveEndpoint))
+	require.Equal(t, "https://archive-api.open-meteo.com/v1/archive", omgo.DefaultEndpoints[omgo.ArchiveEndpoint])
+}
+
+func TestGetFromRoutesToEndpoint(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL+"/v1/forecast")
+	c.SetEndpoint(omgo.ArchiveEndpoint, srv.URL+"/v1/archive")
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	_, err = c.Get(context.Background(), loc, nil)
+	require.NoError(t, err)
+	_, err = c.GetFrom(context.Background(), omgo.ArchiveEndpoint, loc, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"/v1/forecast", "/v1/archive"}, paths)
+}
+
+type keyRecordingCache struct {
+	*omgo.MemoryCache
+	mu   sync.Mutex
+	keys []string
+}
+
+func (c *keyRecordingCache) Set(key string, data []byte, ttl time.Duration) {
+	c.mu.Lock()
+	c.keys = append(c.keys, key)
+	c.mu.Unlock()
+	c.MemoryCache.Set(key, data, ttl)
+}
+
+func TestAPIKeyNotInCacheKeys(t *testing.T) {
+	var apiKeys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKeys = append(apiKeys, r.URL.Query().Get("apikey"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	cache := &keyRecordingCache{MemoryCache: omgo.NewCache()}
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.Cache = cache
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL+"/v1/forecast")
+	c.SetAPIKey("secret_key")
+
+	// Coordinates within a few meters share a cache entry
+	loc1, err := omgo.NewLocation(52.37381, 4.89101)
+	require.NoError(t, err)
+	loc2, err := omgo.NewLocation(52.37382, 4.89102)
+	require.NoError(t, err)
+
+	_, err = c.Get(context.Background(), loc1, &omgo.Options{HourlyMetrics: []string{"temperature_2m"}, Timezone: "UTC"})
+	require.NoError(t, err)
+	_, err = c.Get(context.Background(), loc2, &omgo.Options{Timezone: "UTC", HourlyMetrics: []string{"temperature_2m"}})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"secret_key"}, apiKeys)
+	require.Len(t, cache.keys, 1)
+	require.Equal(t, srv.URL+"/v1/forecast?current_weather=true&hourly=temperature_2m&latitude=52.3738&longitude=4.891&timezone=UTC", cache.keys[0])
+}
+
+func TestAPIKeyNotInTransportErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Close() // Requests to a closed server fail with a connection error
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.Retry = omgo.RetryPolicy{}
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+	c.SetAPIKey("secret_key")
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	_, err = c.Forecast(context.Background(), loc, nil)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "secret_key")
+	require.Contains(t, err.Error(), "apikey=REDACTED")
+	require.True(t, omgo.IsTransportError(err))
+}
+
+func TestOptionsQuery(t *testing.T) {
+	loc, err := omgo.NewLocation(52.123456789, -4.5)
+	require.NoError(t, err)
+
+	opts := &omgo.Options{
+		Timezone:      "America/New_York",
+		StartDate:     "2024-01-01&hourly=x+y",
+		HourlyMetrics: []string{omgo.HourlyTemperature2m, omgo.HourlyRain},
+		PastDays:      2,
+	}
+
+	q := opts.Query(loc)
+	require.Equal(t, "52.123456789", q.Get("latitude"))
+	require.Equal(t, "-4.5", q.Get("longitude"))
+	require.Equal(t, "America/New_York", q.Get("timezone"))
+	require.Equal(t, "2024-01-01&hourly=x+y", q.Get("start_date"))
+	require.Equal(t, "temperature_2m,rain", q.Get("hourly"))
+	require.Equal(t, "current_weather=true&hourly=temperature_2m%2Crain&latitude=52.123456789&longitude=-4.5&past_days=2&start_date=2024-01-01%26hourly%3Dx%2By&timezone=America%2FNew_York", q.Encode())
+
+	// Without locations only the parameters of the options are returned
+	q = opts.Query()
+	require.Empty(t, q.Get("latitude"))
+	require.Equal(t, "2", q.Get("past_days"))
+
+	var nilOpts *omgo.Options
+	require.Equal(t, "current_weather=true", nilOpts.Query().Encode())
+}
+
+func TestQueryEscapedOnTheWire(t *testing.T) {
+	var received url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.URL.Query()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+
+	loc, err := omgo.NewLocation(40.712776, -74.005974) // New York
+	require.NoError(t, err)
+
+	opts := &omgo.Options{Timezone: "America/New_York", HourlyMetrics: []string{omgo.HourlyTemperature2m}}
+	_, err = c.Forecast(context.Background(), loc, opts)
+	require.NoError(t, err)
+	require.Equal(t, opts.Query(loc), received)
+}
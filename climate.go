@@ -0,0 +1,191 @@
+package omgo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CMIP6 models available in the Climate API
+const (
+	ClimateModelCMCC_CM2_VHR4 = "CMCC_CM2_VHR4"
+	ClimateModelFGOALS_f3_H   = "FGOALS_f3_H"
+	ClimateModelHiRAM_SIT_HR  = "HiRAM_SIT_HR"
+	ClimateModelMRI_AGCM3_2_S = "MRI_AGCM3_2_S"
+	ClimateModelEC_Earth3P_HR = "EC_Earth3P_HR"
+	ClimateModelMPI_ESM1_2_XR = "MPI_ESM1_2_XR"
+	ClimateModelNICAM16_8S    = "NICAM16_8S"
+)
+
+// The Climate API covers 1950-01-01 up to and including 2050-12-31
+var (
+	climateFirstDate = time.Date(1950, time.January, 1, 0, 0, 0, 0, time.UTC)
+	climateLastDate  = time.Date(2050, time.December, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// ClimateOptions holds the request options for the Climate API
+type ClimateOptions struct {
+	Models                []string      // One or more CMIP6 models, see the ClimateModel constants
+	StartDate             string        // Start date, 1950-01-01 or later (format: YYYY-MM-DD)
+	EndDate               string        // End date, 2050-12-31 or earlier (format: YYYY-MM-DD)
+	DailyMetrics          []string      // Lists required daily metrics, see https://open-meteo.com/en/docs/climate-api for valid metrics
+	TemperatureUnit       string        // Default "celsius"
+	WindspeedUnit         string        // Default "kmh"
+	PrecipitationUnit     string        // Default "mm"
+	DisableBiasCorrection bool          // Return raw model output instead of bias corrected data
+	CacheTTL              time.Duration // Overrides the Client's TTLPolicy for this request, negative disables caching
+}
+
+// ClimateProjection is the parsed response of the Climate API.
+//
+// The API suffixes every daily metric with the model name when multiple models are
+// requested (e.g. temperature_2m_max_EC_Earth3P_HR). Models holds the series per model,
+// keyed by the plain metric name
+type ClimateProjection struct {
+	Latitude       float64
+	Longitude      float64
+	Elevation      float64
+	GenerationTime float64
+	StartDate      time.Time // Midnight in the timezone of the response, like DailyTimes
+	EndDate        time.Time
+	DailyUnits     map[string]string
+	DailyTimes     []time.Time
+	Models         map[string]map[string][]float64
+	Stale          bool // Served from the cache after expiry, see StalePolicy
+
+	UTCOffsetSeconds     int
+	Timezone             string
+	TimezoneAbbreviation string
+}
+
+// ClimateProjection retrieves daily CMIP6 climate projections for the provided location
+func (c Client) ClimateProjection(ctx context.Context, loc Location, opts *ClimateOptions) (*ClimateProjection, error) {
+	if opts == nil {
+		return nil, ErrInvalidInput{Param: "options", Value: nil}
+	}
+	if len(opts.Models) == 0 {
+		return nil, ErrInvalidInput{Param: "models", Value: "empty"}
+	}
+	if len(opts.DailyMetrics) == 0 {
+		return nil, ErrInvalidInput{Param: "daily", Value: "empty"}
+	}
+
+	startDate, err := time.Parse("2006-01-02", opts.StartDate)
+	if err != nil || startDate.Before(climateFirstDate) || startDate.After(climateLastDate) {
+		return nil, ErrInvalidInput{Param: "start_date", Value: opts.StartDate}
+	}
+
+	endDate, err := time.Parse("2006-01-02", opts.EndDate)
+	if err != nil || endDate.Before(startDate) || endDate.After(climateLastDate) {
+		return nil, ErrInvalidInput{Param: "end_date", Value: opts.EndDate}
+	}
+
+	body, stale, err := c.send(ctx, request{Endpoint: ClimateEndpoint, Query: queryFromClimateOptions(loc, opts), TTL: opts.CacheTTL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get climate data: %w", err)
+	}
+
+	cp, err := ParseClimateBody(body, opts.Models)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse climate data: %w", err)
+	}
+	tz := responseLocation(cp.Timezone, cp.TimezoneAbbreviation, cp.UTCOffsetSeconds)
+	cp.StartDate = inLocation(startDate, tz)
+	cp.EndDate = inLocation(endDate, tz)
+	cp.Stale = stale
+
+	return cp, nil
+}
+
+func queryFromClimateOptions(loc Location, opts *ClimateOptions) url.Values {
+	q := url.Values{}
+	setLocations(q, loc)
+
+	q.Set("models", strings.Join(opts.Models, ","))
+	q.Set("daily", strings.Join(opts.DailyMetrics, ","))
+	q.Set("start_date", opts.StartDate)
+	q.Set("end_date", opts.EndDate)
+
+	if opts.TemperatureUnit != "" {
+		q.Set("temperature_unit", opts.TemperatureUnit)
+	}
+	if opts.WindspeedUnit != "" {
+		q.Set("windspeed_unit", opts.WindspeedUnit)
+	}
+	if opts.PrecipitationUnit != "" {
+		q.Set("precipitation_unit", opts.PrecipitationUnit)
+	}
+	if opts.DisableBiasCorrection {
+		q.Set("disable_bias_correction", "true")
+	}
+
+	return q
+}
+
+// ParseClimateBody converts the Climate API response body into a ClimateProjection,
+// splitting the model suffixed metrics per requested model. When a single model is
+// requested the API does not suffix the metrics, they are then attributed to that model
+func ParseClimateBody(body []byte, models []string) (*ClimateProjection, error) {
+	f := &ForecastJSON{}
+	err := json.Unmarshal(body, f)
+	if err != nil {
+		return nil, err
+	}
+
+	cp := &ClimateProjection{
+		Latitude:       f.Latitude,
+		Longitude:      f.Longitude,
+		Elevation:      f.Elevation,
+		GenerationTime: f.GenerationTime,
+		DailyUnits:     make(map[string]string),
+		Models:         make(map[string]map[string][]float64),
+
+		UTCOffsetSeconds:     f.UTCOffsetSeconds,
+		Timezone:             f.Timezone,
+		TimezoneAbbreviation: f.TimezoneAbbreviation,
+	}
+
+	var metrics map[string][]float64
+	cp.DailyTimes, metrics, err = parseDailyMetrics(f.DailyMetrics, f.location())
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range metrics {
+		metric, model, ok := splitModelKey(k, models)
+		if !ok {
+			return nil, fmt.Errorf("unable to attribute daily metric %q to a model", k)
+		}
+		if cp.Models[model] == nil {
+			cp.Models[model] = make(map[string][]float64)
+		}
+		cp.Models[model][metric] = v
+	}
+
+	for k, v := range f.DailyUnits {
+		metric, _, ok := splitModelKey(k, models)
+		if !ok {
+			metric = k
+		}
+		cp.DailyUnits[metric] = v
+	}
+
+	return cp, nil
+}
+
+// splitModelKey strips the model suffix from a metric key such as
+// "temperature_2m_max_EC_Earth3P_HR"
+func splitModelKey(key string, models []string) (metric string, model string, ok bool) {
+	for _, m := range models {
+		if strings.HasSuffix(key, "_"+m) {
+			return strings.TrimSuffix(key, "_"+m), m, true
+		}
+	}
+	if len(models) == 1 {
+		return key, models[0], true
+	}
+	return key, "", false
+}
@@ -0,0 +1,128 @@
+package omgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClimateProjection(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	opts := &omgo.ClimateOptions{
+		Models:       []string{omgo.ClimateModelEC_Earth3P_HR, omgo.ClimateModelMRI_AGCM3_2_S},
+		StartDate:    "2049-12-01",
+		EndDate:      "2049-12-31",
+		DailyMetrics: []string{"temperature_2m_max"},
+	}
+
+	res, err := c.ClimateProjection(context.Background(), loc, opts)
+	require.NoError(t, err)
+
+	require.Len(t, res.DailyTimes, 31)
+	require.Len(t, res.Models, 2)
+	require.Len(t, res.Models[omgo.ClimateModelEC_Earth3P_HR]["temperature_2m_max"], 31)
+	require.Len(t, res.Models[omgo.ClimateModelMRI_AGCM3_2_S]["temperature_2m_max"], 31)
+}
+
+func TestClimateProjection_InvalidOptions(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	_, err = c.ClimateProjection(context.Background(), loc, nil)
+	require.IsType(t, omgo.ErrInvalidInput{}, err)
+
+	opts := &omgo.ClimateOptions{
+		Models:       []string{omgo.ClimateModelEC_Earth3P_HR},
+		StartDate:    "1949-12-31", // Invalid: before 1950
+		EndDate:      "2000-01-01",
+		DailyMetrics: []string{"temperature_2m_max"},
+	}
+	_, err = c.ClimateProjection(context.Background(), loc, opts)
+	require.IsType(t, omgo.ErrInvalidInput{}, err)
+
+	opts.StartDate = "2040-01-01"
+	opts.EndDate = "2051-01-01" // Invalid: after 2050
+	_, err = c.ClimateProjection(context.Background(), loc, opts)
+	require.IsType(t, omgo.ErrInvalidInput{}, err)
+
+	opts.EndDate = "2039-01-01" // Invalid: before start date
+	_, err = c.ClimateProjection(context.Background(), loc, opts)
+	require.IsType(t, omgo.ErrInvalidInput{}, err)
+
+	opts.EndDate = "2040-01-31"
+	opts.Models = nil
+	_, err = c.ClimateProjection(context.Background(), loc, opts)
+	require.IsType(t, omgo.ErrInvalidInput{}, err)
+}
+
+func TestParseClimateBody(t *testing.T) {
+	body := []byte(`{
+		"latitude": 52.4,
+		"longitude": 4.9,
+		"daily_units": {
+			"time": "iso8601",
+			"temperature_2m_max_EC_Earth3P_HR": "°C",
+			"temperature_2m_max_MRI_AGCM3_2_S": "°C"
+		},
+		"daily": {
+			"time": ["2049-12-01", "2049-12-02"],
+			"temperature_2m_max_EC_Earth3P_HR": [6.1, 5.4],
+			"temperature_2m_max_MRI_AGCM3_2_S": [7.2, 7.9]
+		}
+	}`)
+
+	cp, err := omgo.ParseClimateBody(body, []string{omgo.ClimateModelEC_Earth3P_HR, omgo.ClimateModelMRI_AGCM3_2_S})
+	require.NoError(t, err)
+	require.Equal(t, []float64{6.1, 5.4}, cp.Models[omgo.ClimateModelEC_Earth3P_HR]["temperature_2m_max"])
+	require.Equal(t, []float64{7.2, 7.9}, cp.Models[omgo.ClimateModelMRI_AGCM3_2_S]["temperature_2m_max"])
+	require.Equal(t, "°C", cp.DailyUnits["temperature_2m_max"])
+	require.Len(t, cp.DailyTimes, 2)
+
+	// A single model is returned without suffix
+	body = []byte(`{"daily": {"time": ["2049-12-01"], "precipitation_sum": [1.2]}}`)
+	cp, err = omgo.ParseClimateBody(body, []string{omgo.ClimateModelNICAM16_8S})
+	require.NoError(t, err)
+	require.Equal(t, []float64{1.2}, cp.Models[omgo.ClimateModelNICAM16_8S]["precipitation_sum"])
+}
+
+func TestClimateProjection_ResponseTimezone(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"utc_offset_seconds": 3600,
+			"timezone": "Europe/Amsterdam",
+			"timezone_abbreviation": "CET",
+			"daily": {"time": ["2049-12-01", "2049-12-02"], "temperature_2m_max": [6.1, 5.4]}
+		}`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ClimateEndpoint, srv.URL)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	res, err := c.ClimateProjection(context.Background(), loc, &omgo.ClimateOptions{
+		Models:       []string{omgo.ClimateModelEC_Earth3P_HR},
+		StartDate:    "2049-12-01",
+		EndDate:      "2049-12-02",
+		DailyMetrics: []string{"temperature_2m_max"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Europe/Amsterdam", res.Timezone)
+	require.True(t, res.StartDate.Equal(res.DailyTimes[0]))
+	require.True(t, res.EndDate.Equal(res.DailyTimes[1]))
+}
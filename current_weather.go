@@ -2,20 +2,37 @@ package omgo
 
 import (
 	"context"
+	"time"
 )
 
 // CurrentWeather returns the current weather for the provided location
 //
 // Units and timezones can be provided using an optional `Options` parameter.
-// Any requested hourly or daily metrics as part of the options are discarded
+// Any requested hourly or daily metrics as part of the options are discarded.
+// Responses are cached following the Current rule of the Client's TTLPolicy
 func (c Client) CurrentWeather(ctx context.Context, loc Location, opts *Options) (CurrentWeather, error) {
-	// Discard requested daily/hourly metrics, as they are not returned as part of the current weather
+	reqOpts := Options{}
 	if opts != nil {
-		opts.DailyMetrics = nil
-		opts.HourlyMetrics = nil
+		reqOpts = *opts
+	}
+
+	// Discard requested daily/hourly metrics, as they are not returned as part of the current weather
+	reqOpts.DailyMetrics = nil
+	reqOpts.HourlyMetrics = nil
+	if reqOpts.CacheTTL == 0 {
+		reqOpts.CacheTTL = c.TTL.Current.expiry(time.Now())
 	}
 
-	body, err := c.Get(ctx, loc, opts)
+	if err := reqOpts.Validate(); err != nil {
+		return CurrentWeather{}, err
+	}
+
+	// Only today is requested, which also keeps the response apart from Forecast responses in
+	// the cache, as those are cached for longer than the Current rule allows
+	q := reqOpts.Query(loc)
+	q.Set("forecast_days", "1")
+
+	body, stale, err := c.send(ctx, request{Endpoint: ForecastEndpoint, Query: q, TTL: reqOpts.CacheTTL})
 	if err != nil {
 		return CurrentWeather{}, err
 	}
@@ -25,5 +42,6 @@ func (c Client) CurrentWeather(ctx context.Context, loc Location, opts *Options)
 		return CurrentWeather{}, err
 	}
 
+	fc.CurrentWeather.Stale = stale
 	return fc.CurrentWeather, err
 }
@@ -2,6 +2,10 @@ package omgo_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
 	"testing"
 
 	"github.com/jdotcurs/omgo"
@@ -19,3 +23,35 @@ func TestCurrentWeather(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, res.Time.IsZero())
 }
+
+func TestCurrentWeather_NotServedFromForecastCache(t *testing.T) {
+	var mu sync.Mutex
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query())
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"current_weather": {"time": "2024-09-10T14:00", "temperature": 18.5}}`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	_, err = c.Forecast(context.Background(), loc, nil)
+	require.NoError(t, err)
+	res, err := c.CurrentWeather(context.Background(), loc, nil)
+	require.NoError(t, err)
+	require.Equal(t, 18.5, res.Temperature)
+	_, err = c.CurrentWeather(context.Background(), loc, nil)
+	require.NoError(t, err)
+
+	// The forecast entry is not reused, the second current weather call is served from the cache
+	require.Len(t, queries, 2)
+	require.Equal(t, "", queries[0].Get("forecast_days"))
+	require.Equal(t, "1", queries[1].Get("forecast_days"))
+}
@@ -0,0 +1,83 @@
+package omgo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// MaxElevationLocations is the maximum number of coordinates the Elevation API accepts per call
+const MaxElevationLocations = 100
+
+// ElevationOptions holds the request options for the Elevation API
+type ElevationOptions struct {
+	CacheTTL time.Duration // Overrides the Client's TTLPolicy for this request, negative disables caching
+}
+
+// Elevation returns the terrain elevation in meters (90m digital elevation model) for every
+// provided location, in input order.
+//
+// Inputs larger than MaxElevationLocations are split into multiple requests automatically. When one
+// of the requests fails, the elevations of the preceding locations are returned along with the error
+func (c Client) Elevation(ctx context.Context, locs []Location) ([]float64, error) {
+	elevations, _, err := c.ElevationWithMeta(ctx, locs, nil)
+	return elevations, err
+}
+
+// ElevationWithMeta is Elevation with options, additionally returning how the elevations were
+// obtained. The result is stale when any of the requests was served stale
+func (c Client) ElevationWithMeta(ctx context.Context, locs []Location, opts *ElevationOptions) ([]float64, ResponseMeta, error) {
+	var meta ResponseMeta
+	if len(locs) == 0 {
+		return nil, meta, ErrInvalidInput{Param: "locations", Value: "empty"}
+	}
+	if opts == nil {
+		opts = &ElevationOptions{}
+	}
+
+	elevations := make([]float64, 0, len(locs))
+	err := c.sendChunked(ctx, ElevationEndpoint, locs, MaxElevationLocations, opts.CacheTTL, queryFromElevationLocations,
+		func(body []byte, n int, stale bool) error {
+			chunkElevations, err := ParseElevationBody(body)
+			if err != nil {
+				return fmt.Errorf("failed to parse elevation data: %w", err)
+			}
+			if len(chunkElevations) != n {
+				return ErrAPIResponse{StatusCode: 0, Message: fmt.Sprintf("expected %d elevations, got %d", n, len(chunkElevations))}
+			}
+
+			elevations = append(elevations, chunkElevations...)
+			meta.Stale = meta.Stale || stale
+			return nil
+		})
+	if err != nil {
+		err = fmt.Errorf("failed to get elevation data: %w", err)
+	}
+	if len(elevations) == 0 {
+		return nil, meta, err
+	}
+
+	return elevations, meta, err
+}
+
+func queryFromElevationLocations(locs []Location) url.Values {
+	q := url.Values{}
+	setLocations(q, locs...)
+	return q
+}
+
+// ParseElevationBody converts the Elevation API response body into a list of elevations
+func ParseElevationBody(body []byte) ([]float64, error) {
+	var data struct {
+		Elevation []float64 `json:"elevation"`
+	}
+
+	err := json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	return data.Elevation, nil
+}
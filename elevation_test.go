@@ -0,0 +1,104 @@
+package omgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElevation(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	amsterdam, err := omgo.NewLocation(52.3738, 4.8910)
+	require.NoError(t, err)
+	zugspitze, err := omgo.NewLocation(47.4211, 10.9853)
+	require.NoError(t, err)
+
+	elevations, err := c.Elevation(context.Background(), []omgo.Location{amsterdam, zugspitze})
+	require.NoError(t, err)
+	require.Len(t, elevations, 2)
+	require.Greater(t, elevations[1], elevations[0])
+}
+
+func TestElevation_Chunking(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// Echo the latitude back as elevation so the order can be verified
+		lats := strings.Split(r.URL.Query().Get("latitude"), ",")
+		if len(lats) > omgo.MaxElevationLocations {
+			t.Errorf("got %d locations in a single request", len(lats))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		elevations := make([]float64, len(lats))
+		for i, lat := range lats {
+			elevations[i], _ = strconv.ParseFloat(lat, 64)
+		}
+		_ = json.NewEncoder(w).Encode(map[string][]float64{"elevation": elevations})
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ElevationEndpoint, srv.URL)
+
+	locs := make([]omgo.Location, 250)
+	for i := range locs {
+		locs[i], err = omgo.NewLocation(float64(i)/10, 0)
+		require.NoError(t, err)
+	}
+
+	elevations, err := c.Elevation(context.Background(), locs)
+	require.NoError(t, err)
+	require.Equal(t, 3, requests)
+	require.Len(t, elevations, 250)
+	for i, e := range elevations {
+		require.InDelta(t, float64(i)/10, e, 0.0001)
+	}
+}
+
+func TestElevation_PartialResults(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		n := len(strings.Split(r.URL.Query().Get("latitude"), ","))
+		_ = json.NewEncoder(w).Encode(map[string][]float64{"elevation": make([]float64, n)})
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ElevationEndpoint, srv.URL)
+	c.Retry = omgo.RetryPolicy{}
+
+	locs := make([]omgo.Location, 150)
+	for i := range locs {
+		locs[i], err = omgo.NewLocation(float64(i)/10, 0)
+		require.NoError(t, err)
+	}
+
+	elevations, err := c.Elevation(context.Background(), locs)
+	require.Error(t, err)
+	require.Len(t, elevations, omgo.MaxElevationLocations)
+}
+
+func TestElevation_NoLocations(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	_, err = c.Elevation(context.Background(), nil)
+	require.IsType(t, omgo.ErrInvalidInput{}, err)
+}
@@ -0,0 +1,290 @@
+package omgo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ensemble models available in the Ensemble API
+const (
+	EnsembleModelICONSeamless = "icon_seamless"
+	EnsembleModelICONGlobal   = "icon_global"
+	EnsembleModelICONEU       = "icon_eu"
+	EnsembleModelICOND2       = "icon_d2"
+	EnsembleModelGFSSeamless  = "gfs_seamless"
+	EnsembleModelGFS025       = "gfs025"
+	EnsembleModelGFS05        = "gfs05"
+	EnsembleModelECMWFIFS04   = "ecmwf_ifs04"
+	EnsembleModelECMWFIFS025  = "ecmwf_ifs025"
+	EnsembleModelGEMGlobal    = "gem_global"
+)
+
+// EnsembleOptions holds the request options for the Ensemble API
+type EnsembleOptions struct {
+	Models            []string      // Ensemble models, see the EnsembleModel constants
+	HourlyMetrics     []string      // Lists required hourly metrics, see https://open-meteo.com/en/docs/ensemble-api for valid metrics
+	TemperatureUnit   string        // Default "celsius"
+	WindspeedUnit     string        // Default "kmh"
+	PrecipitationUnit string        // Default "mm"
+	Timezone          string        // Default "UTC"
+	PastDays          int           // Default 0
+	ForecastDays      int           // Default 7, up to 35 depending on the model
+	CacheTTL          time.Duration // Overrides the Client's TTLPolicy for this request, negative disables caching
+}
+
+// EnsembleForecast is the parsed response of the Ensemble API.
+//
+// Members is indexed by metric and then by member: Members["temperature_2m"][0] is the
+// control run, Members["temperature_2m"][n] is ensemble member n. Missing values, e.g. beyond the
+// horizon of a member, are NaN. When multiple models are requested the metric keys carry the
+// model suffix, e.g. "temperature_2m_icon_seamless"
+type EnsembleForecast struct {
+	Latitude       float64
+	Longitude      float64
+	Elevation      float64
+	GenerationTime float64
+	HourlyUnits    map[string]string
+	HourlyTimes    []time.Time
+	Members        map[string][][]float64
+	Stale          bool // Served from the cache after expiry, see StalePolicy
+}
+
+// Ensemble retrieves the hourly ensemble forecast for the provided location
+func (c Client) Ensemble(ctx context.Context, loc Location, opts *EnsembleOptions) (*EnsembleForecast, error) {
+	if opts == nil {
+		return nil, ErrInvalidInput{Param: "options", Value: nil}
+	}
+	if len(opts.HourlyMetrics) == 0 {
+		return nil, ErrInvalidInput{Param: "hourly", Value: "empty"}
+	}
+
+	// Work on a copy, so the default model doesn't end up in the caller's options
+	reqOpts := *opts
+	if len(reqOpts.Models) == 0 {
+		reqOpts.Models = []string{EnsembleModelICONSeamless}
+	}
+
+	body, stale, err := c.send(ctx, request{Endpoint: EnsembleEndpoint, Query: queryFromEnsembleOptions(loc, &reqOpts), TTL: reqOpts.CacheTTL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ensemble data: %w", err)
+	}
+
+	ef, err := ParseEnsembleBody(body, reqOpts.Models)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ensemble data: %w", err)
+	}
+
+	ef.Stale = stale
+
+	return ef, nil
+}
+
+func queryFromEnsembleOptions(loc Location, opts *EnsembleOptions) url.Values {
+	q := url.Values{}
+	setLocations(q, loc)
+
+	q.Set("models", strings.Join(opts.Models, ","))
+	q.Set("hourly", strings.Join(opts.HourlyMetrics, ","))
+
+	if opts.TemperatureUnit != "" {
+		q.Set("temperature_unit", opts.TemperatureUnit)
+	}
+	if opts.WindspeedUnit != "" {
+		q.Set("windspeed_unit", opts.WindspeedUnit)
+	}
+	if opts.PrecipitationUnit != "" {
+		q.Set("precipitation_unit", opts.PrecipitationUnit)
+	}
+	if opts.Timezone != "" {
+		q.Set("timezone", opts.Timezone)
+	}
+	if opts.PastDays != 0 {
+		q.Set("past_days", strconv.Itoa(opts.PastDays))
+	}
+	if opts.ForecastDays != 0 {
+		q.Set("forecast_days", strconv.Itoa(opts.ForecastDays))
+	}
+
+	return q
+}
+
+// ParseEnsembleBody converts the Ensemble API response body into an EnsembleForecast,
+// grouping the "_memberNN" suffixed hourly metrics per metric
+func ParseEnsembleBody(body []byte, models []string) (*EnsembleForecast, error) {
+	f := &ForecastJSON{}
+	err := json.Unmarshal(body, f)
+	if err != nil {
+		return nil, err
+	}
+
+	ef := &EnsembleForecast{
+		Latitude:       f.Latitude,
+		Longitude:      f.Longitude,
+		Elevation:      f.Elevation,
+		GenerationTime: f.GenerationTime,
+		HourlyUnits:    make(map[string]string),
+		Members:        make(map[string][][]float64),
+	}
+
+	ef.HourlyTimes, _, err = parseHourlyMetrics(map[string]json.RawMessage{"time": f.HourlyMetrics["time"]}, f.location())
+	if err != nil {
+		return nil, err
+	}
+
+	for k, raw := range f.HourlyMetrics {
+		if k == "time" {
+			continue
+		}
+		v, err := parseMemberValues(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		metric, member := splitEnsembleKey(k, models)
+		for len(ef.Members[metric]) <= member {
+			ef.Members[metric] = append(ef.Members[metric], nil)
+		}
+		ef.Members[metric][member] = v
+	}
+
+	for k, v := range f.HourlyUnits {
+		metric, _ := splitEnsembleKey(k, models)
+		ef.HourlyUnits[metric] = v
+	}
+
+	return ef, nil
+}
+
+// parseMemberValues parses the series of a single member, null values become NaN so they can be
+// told apart from actual zeros
+func parseMemberValues(raw json.RawMessage) ([]float64, error) {
+	var target []*float64
+	if err := json.Unmarshal(raw, &target); err != nil {
+		return nil, err
+	}
+
+	values := make([]float64, len(target))
+	for i, v := range target {
+		if v == nil {
+			values[i] = math.NaN()
+			continue
+		}
+		values[i] = *v
+	}
+	return values, nil
+}
+
+// splitEnsembleKey splits a key such as "temperature_2m_member03_gfs025" into the metric and
+// the member number. The model suffix is kept on the metric when multiple models are requested
+func splitEnsembleKey(key string, models []string) (metric string, member int) {
+	metric, model := key, ""
+	if len(models) > 1 {
+		if m, mod, ok := splitModelKey(key, models); ok {
+			metric, model = m, mod
+		}
+	}
+
+	metric, member, _ = splitMemberKey(metric)
+	if model != "" {
+		metric = metric + "_" + model
+	}
+	return metric, member
+}
+
+// Mean returns the ensemble mean of the metric for every time step
+func (ef *EnsembleForecast) Mean(metric string) []float64 {
+	return ef.aggregate(metric, func(values []float64) float64 {
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	})
+}
+
+// Spread returns the ensemble spread (standard deviation across members) of the metric
+// for every time step
+func (ef *EnsembleForecast) Spread(metric string) []float64 {
+	return ef.aggregate(metric, func(values []float64) float64 {
+		mean := 0.0
+		for _, v := range values {
+			mean += v
+		}
+		mean /= float64(len(values))
+
+		variance := 0.0
+		for _, v := range values {
+			variance += (v - mean) * (v - mean)
+		}
+		return math.Sqrt(variance / float64(len(values)))
+	})
+}
+
+// Percentile returns the p-th percentile (0-100) across members of the metric for every
+// time step, linearly interpolating between the closest members
+func (ef *EnsembleForecast) Percentile(metric string, p float64) []float64 {
+	p = math.Max(0, math.Min(100, p))
+	return ef.aggregate(metric, func(values []float64) float64 {
+		sort.Float64s(values)
+		rank := p / 100 * float64(len(values)-1)
+		lo := int(math.Floor(rank))
+		hi := int(math.Ceil(rank))
+		return values[lo] + (values[hi]-values[lo])*(rank-float64(lo))
+	})
+}
+
+// ExceedanceProbability returns the fraction (0-1) of members in which the metric is above
+// the threshold for every time step
+func (ef *EnsembleForecast) ExceedanceProbability(metric string, threshold float64) []float64 {
+	return ef.aggregate(metric, func(values []float64) float64 {
+		n := 0
+		for _, v := range values {
+			if v > threshold {
+				n++
+			}
+		}
+		return float64(n) / float64(len(values))
+	})
+}
+
+// aggregate applies fn to the member values of every time step, skipping missing (NaN) values.
+// Time steps without any value are NaN. The values slice passed to fn is a scratch copy and may
+// be reordered
+func (ef *EnsembleForecast) aggregate(metric string, fn func(values []float64) float64) []float64 {
+	members := ef.Members[metric]
+	if len(members) == 0 {
+		return nil
+	}
+
+	steps := 0
+	for _, m := range members {
+		if len(m) > steps {
+			steps = len(m)
+		}
+	}
+
+	result := make([]float64, steps)
+	values := make([]float64, 0, len(members))
+	for i := range result {
+		values = values[:0]
+		for _, m := range members {
+			if i < len(m) && !math.IsNaN(m[i]) {
+				values = append(values, m[i])
+			}
+		}
+		if len(values) == 0 {
+			result[i] = math.NaN()
+			continue
+		}
+		result[i] = fn(values)
+	}
+
+	return result
+}
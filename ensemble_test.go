@@ -0,0 +1,145 @@
+package omgo_test
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsemble(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	opts := &omgo.EnsembleOptions{
+		Models:        []string{omgo.EnsembleModelICONSeamless},
+		HourlyMetrics: []string{"temperature_2m"},
+	}
+
+	res, err := c.Ensemble(context.Background(), loc, opts)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, res.HourlyTimes)
+	require.Greater(t, len(res.Members["temperature_2m"]), 1)
+	require.Len(t, res.Mean("temperature_2m"), len(res.HourlyTimes))
+}
+
+func TestEnsemble_InvalidOptions(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	_, err = c.Ensemble(context.Background(), loc, nil)
+	require.IsType(t, omgo.ErrInvalidInput{}, err)
+
+	_, err = c.Ensemble(context.Background(), loc, &omgo.EnsembleOptions{})
+	require.IsType(t, omgo.ErrInvalidInput{}, err)
+}
+
+var ensembleBody = []byte(`{
+	"latitude": 52.38,
+	"longitude": 4.9,
+	"hourly_units": {"time": "iso8601", "temperature_2m": "°C", "temperature_2m_member01": "°C"},
+	"hourly": {
+		"time": ["2024-09-10T00:00", "2024-09-10T01:00"],
+		"temperature_2m": [10, 20],
+		"temperature_2m_member01": [12, 22],
+		"temperature_2m_member02": [14, 24],
+		"temperature_2m_member03": [16, 26]
+	}
+}`)
+
+func TestParseEnsembleBody(t *testing.T) {
+	ef, err := omgo.ParseEnsembleBody(ensembleBody, []string{omgo.EnsembleModelICONSeamless})
+	require.NoError(t, err)
+	require.Len(t, ef.HourlyTimes, 2)
+	require.Equal(t, [][]float64{{10, 20}, {12, 22}, {14, 24}, {16, 26}}, ef.Members["temperature_2m"])
+	require.Equal(t, "°C", ef.HourlyUnits["temperature_2m"])
+}
+
+func TestParseEnsembleBody_MultipleModels(t *testing.T) {
+	body := []byte(`{
+		"hourly": {
+			"time": ["2024-09-10T00:00"],
+			"temperature_2m_icon_seamless": [10],
+			"temperature_2m_member01_icon_seamless": [11],
+			"temperature_2m_gfs025": [12],
+			"temperature_2m_member01_gfs025": [13]
+		}
+	}`)
+
+	ef, err := omgo.ParseEnsembleBody(body, []string{omgo.EnsembleModelICONSeamless, omgo.EnsembleModelGFS025})
+	require.NoError(t, err)
+	require.Equal(t, [][]float64{{10}, {11}}, ef.Members["temperature_2m_icon_seamless"])
+	require.Equal(t, [][]float64{{12}, {13}}, ef.Members["temperature_2m_gfs025"])
+}
+
+func TestEnsembleAggregations(t *testing.T) {
+	ef, err := omgo.ParseEnsembleBody(ensembleBody, []string{omgo.EnsembleModelICONSeamless})
+	require.NoError(t, err)
+
+	require.Equal(t, []float64{13, 23}, ef.Mean("temperature_2m"))
+	require.InDeltaSlice(t, []float64{2.2360, 2.2360}, ef.Spread("temperature_2m"), 0.001)
+	require.Equal(t, []float64{13, 23}, ef.Percentile("temperature_2m", 50))
+	require.Equal(t, []float64{10, 20}, ef.Percentile("temperature_2m", 0))
+	require.Equal(t, []float64{16, 26}, ef.Percentile("temperature_2m", 100))
+	require.InDeltaSlice(t, []float64{14.5, 24.5}, ef.Percentile("temperature_2m", 75), 0.001)
+	require.Equal(t, []float64{0.5, 1}, ef.ExceedanceProbability("temperature_2m", 13))
+	require.Nil(t, ef.Mean("precipitation"))
+}
+
+func TestEnsembleAggregations_MissingMembers(t *testing.T) {
+	body := []byte(`{
+		"hourly": {
+			"time": ["2024-09-10T00:00", "2024-09-10T01:00", "2024-09-10T02:00"],
+			"temperature_2m": [10, 20, null],
+			"temperature_2m_member01": [12, null, null],
+			"temperature_2m_member02": [14, 30, null]
+		}
+	}`)
+
+	ef, err := omgo.ParseEnsembleBody(body, []string{omgo.EnsembleModelICONSeamless})
+	require.NoError(t, err)
+	require.True(t, math.IsNaN(ef.Members["temperature_2m"][1][1]))
+
+	mean := ef.Mean("temperature_2m")
+	require.Equal(t, []float64{12, 25}, mean[:2])
+	require.True(t, math.IsNaN(mean[2]))
+
+	require.Equal(t, []float64{14, 30}, ef.Percentile("temperature_2m", 100)[:2])
+	require.Equal(t, []float64{0, 0.5}, ef.ExceedanceProbability("temperature_2m", 25)[:2])
+	require.InDeltaSlice(t, []float64{1.633, 5}, ef.Spread("temperature_2m")[:2], 0.001)
+	require.True(t, math.IsNaN(ef.Spread("temperature_2m")[2]))
+}
+
+func TestEnsemble_OptionsUntouched(t *testing.T) {
+	var models string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		models = r.URL.Query().Get("models")
+		_, _ = w.Write(ensembleBody)
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.EnsembleEndpoint, srv.URL)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	opts := &omgo.EnsembleOptions{HourlyMetrics: []string{"temperature_2m"}}
+	ef, err := c.Ensemble(context.Background(), loc, opts)
+	require.NoError(t, err)
+	require.Len(t, ef.Members["temperature_2m"], 4)
+	require.Equal(t, omgo.EnsembleModelICONSeamless, models)
+	require.Nil(t, opts.Models)
+}
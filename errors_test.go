@@ -1,6 +1,12 @@
 package omgo_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/jdotcurs/omgo"
@@ -21,3 +27,135 @@ func TestErrRateLimit_Error(t *testing.T) {
 	err := omgo.ErrRateLimit{Message: "Too many requests"}
 	require.Equal(t, "rate limit exceeded: Too many requests", err.Error())
 }
+
+func TestErrAPIReason_Error(t *testing.T) {
+	err := omgo.ErrAPIReason{StatusCode: 400, Reason: "Latitude must be in range of -90 to 90°. Given: 1000.0.", URL: "https://api.open-meteo.com/v1/forecast"}
+	require.Equal(t, "API error (status 400): Latitude must be in range of -90 to 90°. Given: 1000.0. (https://api.open-meteo.com/v1/forecast)", err.Error())
+}
+
+func TestErrInvalidInput_Is(t *testing.T) {
+	var err error = omgo.ErrInvalidInput{Param: "test", Value: 123}
+	require.True(t, errors.Is(err, omgo.ErrInvalidParameter))
+	require.False(t, errors.Is(err, omgo.ErrUnknownVariable))
+}
+
+func TestAPIErrorEnvelope(t *testing.T) {
+	reasons := map[string]error{
+		"Cannot initialize ForecastVariable from invalid String value tempeture_2m for key hourly": omgo.ErrUnknownVariable,
+		"Parameter 'start_date' is out of allowed range from 2022-06-08 to 2024-09-25":             omgo.ErrDateOutOfRange,
+		"Latitude must be in range of -90 to 90°. Given: 1000.0.":                                  omgo.ErrInvalidParameter,
+	}
+
+	for reason, sentinel := range reasons {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": true, "reason": reason})
+		}))
+
+		c, err := omgo.NewClient()
+		require.NoError(t, err)
+		c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+		c.SetAPIKey("secret_key")
+
+		loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+		require.NoError(t, err)
+
+		_, err = c.Forecast(context.Background(), loc, nil)
+		srv.Close()
+
+		var apiErr omgo.ErrAPIReason
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+		require.Equal(t, reason, apiErr.Reason)
+		require.NotContains(t, apiErr.URL, "secret_key")
+		require.NotContains(t, err.Error(), "secret_key")
+		require.True(t, errors.Is(err, sentinel))
+		require.True(t, errors.Is(err, omgo.ErrInvalidParameter))
+	}
+}
+
+func TestAPIErrorRedactsCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": true, "reason": "Invalid latitude"}`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.Retry = omgo.RetryPolicy{}
+	c.SetEndpoint(omgo.ForecastEndpoint, strings.Replace(srv.URL, "http://", "http://proxy_user:proxy_password@", 1))
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	_, err = c.Forecast(context.Background(), loc, nil)
+	var apiErr omgo.ErrAPIReason
+	require.True(t, errors.As(err, &apiErr))
+	require.True(t, strings.HasPrefix(apiErr.URL, srv.URL+"?"))
+	require.NotContains(t, err.Error(), "proxy_user")
+	require.NotContains(t, err.Error(), "proxy_password")
+
+	// Transport errors as well
+	srv.Close()
+	_, err = c.Forecast(context.Background(), loc, &omgo.Options{PastDays: 1})
+	require.True(t, omgo.IsTransportError(err))
+	require.NotContains(t, err.Error(), "proxy_user")
+	require.NotContains(t, err.Error(), "proxy_password")
+}
+
+func TestAPIErrorWithoutEnvelope(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("Bad Gateway"))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	_, err = c.Forecast(context.Background(), loc, nil)
+	require.Equal(t, omgo.ErrAPIResponse{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"}, err)
+	require.False(t, errors.Is(err, omgo.ErrInvalidParameter))
+}
+
+func TestAPIKeyRejected(t *testing.T) {
+	responses := []struct {
+		status   int
+		body     string
+		sentinel error
+	}{
+		{http.StatusUnauthorized, `{"error": true, "reason": "Invalid API key"}`, omgo.ErrInvalidAPIKey},
+		{http.StatusForbidden, `{"error": true, "reason": "API key expired on 2024-01-31"}`, omgo.ErrAPIKeyExpired},
+		{http.StatusUnauthorized, "Unauthorized", omgo.ErrInvalidAPIKey},
+	}
+
+	for _, resp := range responses {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(resp.status)
+			_, _ = w.Write([]byte(resp.body))
+		}))
+
+		c, err := omgo.NewClient()
+		require.NoError(t, err)
+		c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+		c.SetAPIKey("secret_key")
+
+		loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+		require.NoError(t, err)
+
+		_, err = c.Forecast(context.Background(), loc, nil)
+		srv.Close()
+
+		var apiErr omgo.ErrAPIReason
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, resp.status, apiErr.StatusCode)
+		require.True(t, errors.Is(err, resp.sentinel))
+		require.False(t, errors.Is(err, omgo.ErrInvalidParameter))
+		require.NotContains(t, err.Error(), "secret_key")
+	}
+}
@@ -40,10 +40,26 @@ func main() {
 		{"Rio de Janeiro", -22.9068, -43.1729},
 	}
 
+	// Fetch the forecasts of all cities concurrently, a failing city doesn't stop the others
+	jobs := make([]omgo.BatchJob, len(cities))
+	for i, city := range cities {
+		loc, err := omgo.NewLocation(city.Lat, city.Lon)
+		if err != nil {
+			log.Fatalf("Failed to create location for %s: %v", city.Name, err)
+		}
+		jobs[i] = omgo.BatchJob{Location: loc, Options: forecastOptions}
+	}
+
 	var cityWeathers []CityWeather
 
-	for _, city := range cities {
-		weather, err := getCityWeather(client, city.Name, city.Lat, city.Lon)
+	for res := range client.BatchSlice(context.Background(), jobs, 3) {
+		city := cities[res.Index]
+		if res.Err != nil {
+			log.Printf("Failed to get forecast for %s: %v", city.Name, res.Err)
+			continue
+		}
+
+		weather, err := getCityWeather(client, city.Name, city.Lat, city.Lon, res.Forecast)
 		if err != nil {
 			log.Printf("Failed to get weather for %s: %v", city.Name, err)
 			continue
@@ -57,28 +73,24 @@ func main() {
 	printHistoricalData(cityWeathers)
 }
 
-func getCityWeather(client omgo.Client, cityName string, lat, lon float64) (CityWeather, error) {
+var forecastOptions = &omgo.Options{
+	TemperatureUnit:   omgo.TemperatureUnitCelsius,
+	WindspeedUnit:     omgo.WindspeedUnitKmh,
+	PrecipitationUnit: omgo.PrecipitationUnitMm,
+	Timezone:          "UTC",
+	HourlyMetrics:     []string{omgo.HourlyRelativeHumidity2m, omgo.HourlyCloudCover},
+	DailyMetrics:      []string{omgo.DailyPrecipitationSum},
+}
+
+func getCityWeather(client omgo.Client, cityName string, lat, lon float64, forecast *omgo.Forecast) (CityWeather, error) {
 	loc, err := omgo.NewLocation(lat, lon)
 	if err != nil {
 		return CityWeather{}, fmt.Errorf("failed to create location: %w", err)
 	}
 
-	opts := &omgo.Options{
-		TemperatureUnit:   "celsius",
-		WindspeedUnit:     "kmh",
-		PrecipitationUnit: "mm",
-		Timezone:          "UTC",
-		HourlyMetrics:     []string{"relativehumidity_2m", "cloudcover"},
-		DailyMetrics:      []string{"precipitation_sum"},
-		AirQualityMetrics: []string{"pm2_5"},
-	}
-
-	forecast, err := client.Forecast(context.Background(), loc, opts)
-	if err != nil {
-		return CityWeather{}, fmt.Errorf("failed to get forecast: %w", err)
-	}
-
-	airQuality, err := client.GetAirQuality(context.Background(), loc, opts)
+	airQuality, err := client.GetAirQuality(context.Background(), loc, &omgo.AirQualityOptions{
+		CurrentMetrics: []string{"pm2_5"},
+	})
 	if err != nil {
 		return CityWeather{}, fmt.Errorf("failed to get air quality data: %w", err)
 	}
@@ -96,10 +108,10 @@ func getCityWeather(client omgo.Client, cityName string, lat, lon float64) (City
 	return CityWeather{
 		Name:             cityName,
 		Temperature:      forecast.CurrentWeather.Temperature,
-		Humidity:         forecast.HourlyMetrics["relativehumidity_2m"][0],
+		Humidity:         forecast.HourlyMetrics[omgo.HourlyRelativeHumidity2m][0],
 		WindSpeed:        forecast.CurrentWeather.WindSpeed,
-		AirQuality:       airQuality.PM2_5,
-		CloudCover:       forecast.HourlyMetrics["cloudcover"][0],
+		AirQuality:       airQuality.Current.PM2_5,
+		CloudCover:       forecast.HourlyMetrics[omgo.HourlyCloudCover][0],
 		PrecipitationSum: forecast.DailyMetrics["precipitation_sum"][0],
 		HistoricalData:   historicalData,
 		SeasonalForecast: seasonalForecast,
@@ -0,0 +1,98 @@
+package omgo
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const fileCacheExt = ".omgo"
+
+// FileCache is a Cache storing every entry as a file in a directory, so cached responses
+// survive process restarts and can be shared between processes on the same machine
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache returns a FileCache storing its entries in dir, the directory is created
+// when it does not exist yet
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+// path maps a key onto a file name, keys are hashed as they contain URL characters
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+fileCacheExt)
+}
+
+// Get returns the entry stored under key. Expired or unreadable entries are removed
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	p := c.path(key)
+	raw, err := os.ReadFile(p)
+	if err != nil {
+		return nil, false
+	}
+
+	// Entries are stored as the expiration in unix nanoseconds followed by the data
+	if len(raw) < 8 {
+		_ = os.Remove(p)
+		return nil, false
+	}
+	expiration := time.Unix(0, int64(binary.BigEndian.Uint64(raw[:8])))
+	if time.Now().After(expiration) {
+		_ = os.Remove(p)
+		return nil, false
+	}
+
+	return raw[8:], true
+}
+
+// Set stores data under key. The file is written to a temporary file first and then
+// renamed, so concurrent readers never observe a partially written entry
+func (c *FileCache) Set(key string, data []byte, ttl time.Duration) {
+	raw := make([]byte, 8+len(data))
+	binary.BigEndian.PutUint64(raw[:8], uint64(time.Now().Add(ttl).UnixNano()))
+	copy(raw[8:], data)
+
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(raw)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+func (c *FileCache) Delete(key string) {
+	_ = os.Remove(c.path(key))
+}
+
+// Clear removes all cache entries from the directory, other files are left untouched
+func (c *FileCache) Clear() {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), fileCacheExt) {
+			_ = os.Remove(filepath.Join(c.dir, e.Name()))
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package omgo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FloodOptions holds the request options for the Flood API
+type FloodOptions struct {
+	DailyMetrics []string      // Lists required daily metrics, defaults to river_discharge and all its statistics
+	Ensemble     bool          // Return all ensemble members of river_discharge
+	Models       []string      // GloFAS model versions, e.g. "seamless_v4" (default), "forecast_v4" or "consolidated_v4"
+	PastDays     int           // Default 0
+	ForecastDays int           // Default 92, up to 210
+	StartDate    string        // Start date (format: YYYY-MM-DD)
+	EndDate      string        // End date (format: YYYY-MM-DD)
+	CacheTTL     time.Duration // Overrides the Client's TTLPolicy for this request, negative disables caching
+}
+
+// FloodForecast is the parsed response of the Flood API
+type FloodForecast struct {
+	Latitude       float64
+	Longitude      float64
+	GenerationTime float64
+	DailyUnits     map[string]string
+	DailyMetrics   map[string][]float64
+	DailyTimes     []time.Time
+	Daily          FloodDailyData
+	// Members holds every ensemble member's river discharge series when the Ensemble option
+	// is set. Members[0] is the control run, Members[n] is ensemble member n
+	Members [][]float64
+	Stale   bool // Served from the cache after expiry, see StalePolicy
+}
+
+type FloodDailyData struct {
+	Time                 []time.Time
+	RiverDischarge       []float64
+	RiverDischargeMean   []float64
+	RiverDischargeMedian []float64
+	RiverDischargeMax    []float64
+	RiverDischargeMin    []float64
+	RiverDischargeP25    []float64
+	RiverDischargeP75    []float64
+}
+
+var defaultFloodMetrics = []string{
+	"river_discharge",
+	"river_discharge_mean",
+	"river_discharge_median",
+	"river_discharge_max",
+	"river_discharge_min",
+	"river_discharge_p25",
+	"river_discharge_p75",
+}
+
+// Flood retrieves the GloFAS river discharge forecast for the provided location.
+//
+// Without explicit metrics the river discharge and all its statistics are returned. With
+// the Ensemble option set only river_discharge is requested, with one series per member
+func (c Client) Flood(ctx context.Context, loc Location, opts *FloodOptions) (*FloodForecast, error) {
+	// Work on a copy, so the defaults don't end up in the caller's options
+	reqOpts := FloodOptions{}
+	if opts != nil {
+		reqOpts = *opts
+	}
+	if len(reqOpts.DailyMetrics) == 0 {
+		if reqOpts.Ensemble {
+			reqOpts.DailyMetrics = []string{"river_discharge"}
+		} else {
+			reqOpts.DailyMetrics = append([]string(nil), defaultFloodMetrics...)
+		}
+	}
+
+	body, stale, err := c.send(ctx, request{Endpoint: FloodEndpoint, Query: queryFromFloodOptions(loc, &reqOpts), TTL: reqOpts.CacheTTL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flood data: %w", err)
+	}
+
+	ff, err := ParseFloodBody(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse flood data: %w", err)
+	}
+
+	ff.Stale = stale
+
+	return ff, nil
+}
+
+func queryFromFloodOptions(loc Location, opts *FloodOptions) url.Values {
+	q := url.Values{}
+	setLocations(q, loc)
+
+	if len(opts.DailyMetrics) > 0 {
+		q.Set("daily", strings.Join(opts.DailyMetrics, ","))
+	}
+	if opts.Ensemble {
+		q.Set("ensemble", "true")
+	}
+	if len(opts.Models) > 0 {
+		q.Set("models", strings.Join(opts.Models, ","))
+	}
+	if opts.PastDays != 0 {
+		q.Set("past_days", strconv.Itoa(opts.PastDays))
+	}
+	if opts.ForecastDays != 0 {
+		q.Set("forecast_days", strconv.Itoa(opts.ForecastDays))
+	}
+	if opts.StartDate != "" {
+		q.Set("start_date", opts.StartDate)
+	}
+	if opts.EndDate != "" {
+		q.Set("end_date", opts.EndDate)
+	}
+
+	return q
+}
+
+// ParseFloodBody converts the Flood API response body into a FloodForecast struct
+func ParseFloodBody(body []byte) (*FloodForecast, error) {
+	f := &ForecastJSON{}
+	err := json.Unmarshal(body, f)
+	if err != nil {
+		return nil, err
+	}
+
+	ff := &FloodForecast{
+		Latitude:       f.Latitude,
+		Longitude:      f.Longitude,
+		GenerationTime: f.GenerationTime,
+		DailyUnits:     f.DailyUnits,
+	}
+
+	ff.DailyTimes, ff.DailyMetrics, err = parseDailyMetrics(f.DailyMetrics, f.location())
+	if err != nil {
+		return nil, err
+	}
+
+	d := ff.DailyMetrics
+	ff.Daily = FloodDailyData{
+		Time:                 ff.DailyTimes,
+		RiverDischarge:       d["river_discharge"],
+		RiverDischargeMean:   d["river_discharge_mean"],
+		RiverDischargeMedian: d["river_discharge_median"],
+		RiverDischargeMax:    d["river_discharge_max"],
+		RiverDischargeMin:    d["river_discharge_min"],
+		RiverDischargeP25:    d["river_discharge_p25"],
+		RiverDischargeP75:    d["river_discharge_p75"],
+	}
+
+	for k, v := range d {
+		metric, member, ok := splitMemberKey(k)
+		if !ok || metric != "river_discharge" {
+			continue
+		}
+		for len(ff.Members) <= member {
+			ff.Members = append(ff.Members, nil)
+		}
+		ff.Members[member] = v
+	}
+	if len(ff.Members) > 0 {
+		ff.Members[0] = d["river_discharge"]
+	}
+
+	return ff, nil
+}
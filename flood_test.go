@@ -0,0 +1,99 @@
+package omgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlood(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	loc, err := omgo.NewLocation(51.84, 6.1) // Rhine at Lobith
+	require.NoError(t, err)
+
+	res, err := c.Flood(context.Background(), loc, nil)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, res.DailyTimes)
+	require.Equal(t, len(res.DailyTimes), len(res.Daily.RiverDischarge))
+	require.Equal(t, len(res.DailyTimes), len(res.Daily.RiverDischargeP75))
+	require.Empty(t, res.Members)
+}
+
+func TestFlood_Ensemble(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	loc, err := omgo.NewLocation(51.84, 6.1) // Rhine at Lobith
+	require.NoError(t, err)
+
+	res, err := c.Flood(context.Background(), loc, &omgo.FloodOptions{Ensemble: true})
+	require.NoError(t, err)
+
+	require.Greater(t, len(res.Members), 1)
+	for _, m := range res.Members {
+		require.Equal(t, len(res.DailyTimes), len(m))
+	}
+}
+
+func TestParseFloodBody_Ensemble(t *testing.T) {
+	body := []byte(`{
+		"latitude": 51.825,
+		"longitude": 6.125,
+		"daily_units": {"time": "iso8601", "river_discharge": "m³/s"},
+		"daily": {
+			"time": ["2024-09-10", "2024-09-11"],
+			"river_discharge": [1500.1, 1480.3],
+			"river_discharge_member01": [1510.0, 1490.2],
+			"river_discharge_member02": [1495.4, 1470.9]
+		}
+	}`)
+
+	ff, err := omgo.ParseFloodBody(body)
+	require.NoError(t, err)
+	require.Equal(t, []float64{1500.1, 1480.3}, ff.Daily.RiverDischarge)
+	require.Equal(t, [][]float64{
+		{1500.1, 1480.3},
+		{1510.0, 1490.2},
+		{1495.4, 1470.9},
+	}, ff.Members)
+	require.Equal(t, "m³/s", ff.DailyUnits["river_discharge"])
+}
+
+func TestFlood_OptionsUntouched(t *testing.T) {
+	var daily []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		daily = append(daily, r.URL.Query().Get("daily"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.FloodEndpoint, srv.URL)
+	c.Cache = nil
+
+	loc, err := omgo.NewLocation(51.84, 6.1) // Rhine at Lobith
+	require.NoError(t, err)
+
+	opts := &omgo.FloodOptions{}
+	_, err = c.Flood(context.Background(), loc, opts)
+	require.NoError(t, err)
+	require.Nil(t, opts.DailyMetrics)
+
+	opts.Ensemble = true
+	_, err = c.Flood(context.Background(), loc, opts)
+	require.NoError(t, err)
+	require.Nil(t, opts.DailyMetrics)
+
+	_, err = c.Flood(context.Background(), loc, nil)
+	require.NoError(t, err)
+	require.Equal(t, daily[0], daily[2])
+	require.Equal(t, "river_discharge", daily[1])
+}
@@ -10,10 +10,40 @@ import (
 // struct that will contains the current weather, all requested hourly predictions and
 // all requested daily predictions
 func (c Client) Forecast(ctx context.Context, loc Location, opts *Options) (*Forecast, error) {
-	body, err := c.Get(ctx, loc, opts)
+	body, stale, err := c.get(ctx, ForecastEndpoint, loc, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return ParseBody(body)
+	fc, err := ParseBody(body)
+	if err != nil {
+		return nil, err
+	}
+	fc.Stale = stale
+
+	return fc, nil
+}
+
+// ForecastMany retrieves the forecasts for multiple locations in as few API calls as possible,
+// see MaxBatchLocations. The forecasts are returned in input order.
+//
+// When one of the calls fails, e.g. with ErrQuotaExceeded, the forecasts fetched so far are
+// returned along with the error and the remaining ones are nil
+func (c Client) ForecastMany(ctx context.Context, locs []Location, opts *Options) ([]*Forecast, error) {
+	results, stale, fetchErr := c.getMany(ctx, ForecastEndpoint, locs, opts)
+	if len(results) == 0 {
+		return nil, fetchErr
+	}
+
+	forecasts := make([]*Forecast, len(locs))
+	for i, body := range results {
+		fc, err := ParseBody(body)
+		if err != nil {
+			return nil, err
+		}
+		fc.Stale = stale[i]
+		forecasts[i] = fc
+	}
+
+	return forecasts, fetchErr
 }
@@ -2,10 +2,18 @@ package omgo_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/jdotcurs/omgo"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 )
 
 func TestForecast(t *testing.T) {
@@ -21,7 +29,7 @@ func TestForecast(t *testing.T) {
 		PrecipitationUnit: "mm",
 		Timezone:          "UTC",
 		PastDays:          0,
-		HourlyMetrics:     []string{"temperature_2m", "dewpoint_2m"},
+		HourlyMetrics:     []string{"temperature_2m", "dew_point_2m"},
 		DailyMetrics:      []string{"temperature_2m_max"},
 	}
 	res, err := c.Forecast(context.Background(), loc, &opts)
@@ -33,3 +41,144 @@ func TestForecast(t *testing.T) {
 	require.Greater(t, len(res.DailyTimes), 0)
 	require.Equal(t, 1, len(res.DailyMetrics))
 }
+
+// multiLocationServer answers with one result per requested location, as a JSON array for
+// multiple locations and a single object otherwise, like the API does. Malformed locations are
+// answered with 400, failing the request on the client side
+func multiLocationServer(t *testing.T, calls *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		lats := strings.Split(r.URL.Query().Get("latitude"), ",")
+		lons := strings.Split(r.URL.Query().Get("longitude"), ",")
+		if len(lats) != len(lons) {
+			t.Errorf("got %d latitudes and %d longitudes", len(lats), len(lons))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		results := make([]map[string]interface{}, len(lats))
+		for i := range lats {
+			lat, latErr := strconv.ParseFloat(lats[i], 64)
+			lon, lonErr := strconv.ParseFloat(lons[i], 64)
+			if latErr != nil || lonErr != nil {
+				t.Errorf("invalid location %s,%s", lats[i], lons[i])
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			results[i] = map[string]interface{}{
+				"latitude":  lat,
+				"longitude": lon,
+				"hourly":    map[string]interface{}{"time": []string{"2024-01-01T00:00"}, "temperature_2m": []float64{lat}},
+				"daily":     map[string]interface{}{"time": []string{"2024-01-01"}},
+			}
+		}
+
+		if len(results) == 1 {
+			_ = json.NewEncoder(w).Encode(results[0])
+			return
+		}
+		_ = json.NewEncoder(w).Encode(results)
+	}))
+}
+
+func TestForecastMany(t *testing.T) {
+	calls := 0
+	srv := multiLocationServer(t, &calls)
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+
+	locs := make([]omgo.Location, omgo.MaxBatchLocations+50)
+	for i := range locs {
+		locs[i], err = omgo.NewLocation(float64(i)/10, 4.891)
+		require.NoError(t, err)
+	}
+
+	forecasts, err := c.ForecastMany(context.Background(), locs, &omgo.Options{HourlyMetrics: []string{omgo.HourlyTemperature2m}})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+	require.Len(t, forecasts, len(locs))
+	for i, fc := range forecasts {
+		require.Equal(t, float64(i)/10, fc.Latitude)
+		require.Equal(t, []float64{float64(i) / 10}, fc.HourlyMetrics[omgo.HourlyTemperature2m])
+	}
+
+	// A single location is answered with an object instead of an array
+	forecasts, err = c.ForecastMany(context.Background(), []omgo.Location{locs[0]}, nil)
+	require.NoError(t, err)
+	require.Len(t, forecasts, 1)
+	require.Equal(t, 0.0, forecasts[0].Latitude)
+
+	_, err = c.ForecastMany(context.Background(), nil, nil)
+	require.Equal(t, omgo.ErrInvalidInput{Param: "locations", Value: "empty"}, err)
+}
+
+func TestForecastManyAtScale(t *testing.T) {
+	calls := 0
+	srv := multiLocationServer(t, &calls)
+	defer srv.Close()
+
+	locs := make([]omgo.Location, 5000)
+	for i := range locs {
+		var err error
+		locs[i], err = omgo.NewLocation(float64(i)/100, 4.891)
+		require.NoError(t, err)
+	}
+	opts := &omgo.Options{HourlyMetrics: []string{omgo.HourlyTemperature2m}, CacheTTL: -1}
+
+	newClient := func(quota *omgo.Quota) omgo.Client {
+		c, err := omgo.NewClient()
+		require.NoError(t, err)
+		c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+		c.RateLimiter = rate.NewLimiter(rate.Inf, 1)
+		c.Quota = quota
+		return c
+	}
+
+	// No quota is tracked by default
+	forecasts, err := newClient(nil).ForecastMany(context.Background(), locs, opts)
+	require.NoError(t, err)
+	require.Equal(t, 50, calls)
+	require.Len(t, forecasts, len(locs))
+	require.Equal(t, float64(4999)/100, forecasts[4999].Latitude)
+
+	// A waiting quota spreads the calls over several windows, every chunk of 100 locations
+	// weighs 100 calls
+	quota := omgo.NewQuota(omgo.QuotaLimit{Period: 50 * time.Millisecond, Limit: 1000})
+	quota.Wait = true
+	forecasts, err = newClient(quota).ForecastMany(context.Background(), locs, opts)
+	require.NoError(t, err)
+	require.Len(t, forecasts, len(locs))
+	require.NotNil(t, forecasts[4999])
+
+	// Without waiting the forecasts fetched before the quota ran out are kept
+	calls = 0
+	quota = omgo.NewQuota(omgo.QuotaLimit{Period: time.Hour, Limit: 600})
+	forecasts, err = newClient(quota).ForecastMany(context.Background(), locs, opts)
+	require.True(t, errors.As(err, &omgo.ErrQuotaExceeded{}))
+	require.Equal(t, 6, calls)
+	require.Len(t, forecasts, len(locs))
+	require.Equal(t, float64(599)/100, forecasts[599].Latitude)
+	require.Nil(t, forecasts[600])
+}
+
+func TestForecastManyMissingResults(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"latitude": 1}]`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+
+	loc1, err := omgo.NewLocation(1, 1)
+	require.NoError(t, err)
+	loc2, err := omgo.NewLocation(2, 2)
+	require.NoError(t, err)
+
+	_, err = c.ForecastMany(context.Background(), []omgo.Location{loc1, loc2}, nil)
+	require.Equal(t, omgo.ErrAPIResponse{Message: "expected 2 results, got 1"}, err)
+}
@@ -0,0 +1,109 @@
+package omgo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// GeocodeOptions holds the request options for the Geocoding API
+type GeocodeOptions struct {
+	Count       int           // Number of results to return, 1-100. Default 10
+	Language    string        // Language of the returned names, e.g. "de". Default "en"
+	CountryCode string        // ISO-3166-1 alpha2 country code to restrict results to, e.g. "NL"
+	CacheTTL    time.Duration // Overrides the Client's TTLPolicy for this request, negative disables caching
+}
+
+// GeocodeResult is a single place returned by the Geocoding API
+type GeocodeResult struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Latitude    float64  `json:"latitude"`
+	Longitude   float64  `json:"longitude"`
+	Elevation   float64  `json:"elevation"`
+	FeatureCode string   `json:"feature_code"`
+	CountryCode string   `json:"country_code"`
+	Country     string   `json:"country"`
+	Admin1      string   `json:"admin1"`
+	Admin2      string   `json:"admin2"`
+	Admin3      string   `json:"admin3"`
+	Admin4      string   `json:"admin4"`
+	Timezone    string   `json:"timezone"`
+	Population  int      `json:"population"`
+	Postcodes   []string `json:"postcodes"`
+	Location    Location `json:"-"` // Ready to use in any of the other Client methods
+	Stale       bool     `json:"-"` // Served from the cache after expiry, see StalePolicy
+}
+
+// Geocode resolves a place name (or postal code) into a list of candidate places, ordered
+// by relevance. An empty list is returned when nothing matches
+func (c Client) Geocode(ctx context.Context, name string, opts *GeocodeOptions) ([]GeocodeResult, error) {
+	if name == "" {
+		return nil, ErrInvalidInput{Param: "name", Value: name}
+	}
+	if opts == nil {
+		opts = &GeocodeOptions{}
+	}
+	if opts.Count < 0 || opts.Count > 100 {
+		return nil, ErrInvalidInput{Param: "count", Value: opts.Count}
+	}
+
+	body, stale, err := c.send(ctx, request{Endpoint: GeocodingEndpoint, Query: queryFromGeocodeOptions(name, opts), TTL: opts.CacheTTL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get geocoding data: %w", err)
+	}
+
+	results, err := ParseGeocodeBody(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse geocoding data: %w", err)
+	}
+	for i := range results {
+		results[i].Stale = stale
+	}
+
+	return results, nil
+}
+
+func queryFromGeocodeOptions(name string, opts *GeocodeOptions) url.Values {
+	q := url.Values{}
+	q.Set("name", name)
+	q.Set("format", "json")
+
+	if opts.Count != 0 {
+		q.Set("count", strconv.Itoa(opts.Count))
+	}
+	if opts.Language != "" {
+		q.Set("language", opts.Language)
+	}
+	if opts.CountryCode != "" {
+		q.Set("countryCode", opts.CountryCode)
+	}
+
+	return q
+}
+
+// ParseGeocodeBody converts the Geocoding API response body into a list of GeocodeResults
+func ParseGeocodeBody(body []byte) ([]GeocodeResult, error) {
+	var data struct {
+		Results []GeocodeResult `json:"results"`
+	}
+
+	err := json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]GeocodeResult, 0, len(data.Results))
+	for _, r := range data.Results {
+		r.Location, err = NewLocation(r.Latitude, r.Longitude)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,73 @@
+package omgo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeocode(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	results, err := c.Geocode(context.Background(), "Amsterdam", &omgo.GeocodeOptions{Count: 3, Language: "nl"})
+	require.NoError(t, err)
+
+	require.NotEmpty(t, results)
+	require.LessOrEqual(t, len(results), 3)
+	require.Equal(t, "NL", results[0].CountryCode)
+	require.Equal(t, "Europe/Amsterdam", results[0].Timezone)
+
+	_, err = c.Forecast(context.Background(), results[0].Location, nil)
+	require.NoError(t, err)
+}
+
+func TestGeocode_InvalidInput(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	_, err = c.Geocode(context.Background(), "", nil)
+	require.IsType(t, omgo.ErrInvalidInput{}, err)
+
+	_, err = c.Geocode(context.Background(), "Amsterdam", &omgo.GeocodeOptions{Count: 101})
+	require.IsType(t, omgo.ErrInvalidInput{}, err)
+}
+
+func TestParseGeocodeBody(t *testing.T) {
+	body := []byte(`{
+		"results": [{
+			"id": 2950159,
+			"name": "Berlin",
+			"latitude": 52.52437,
+			"longitude": 13.41053,
+			"elevation": 74.0,
+			"feature_code": "PPLC",
+			"country_code": "DE",
+			"timezone": "Europe/Berlin",
+			"population": 3426354,
+			"postcodes": ["10967", "13347"],
+			"country": "Deutschland",
+			"admin1": "Land Berlin"
+		}],
+		"generationtime_ms": 0.6
+	}`)
+
+	results, err := omgo.ParseGeocodeBody(body)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "Berlin", results[0].Name)
+	require.Equal(t, "Land Berlin", results[0].Admin1)
+	require.Equal(t, 3426354, results[0].Population)
+	require.Equal(t, float64(74), results[0].Elevation)
+
+	expected, err := omgo.NewLocation(52.52437, 13.41053)
+	require.NoError(t, err)
+	require.Equal(t, expected, results[0].Location)
+
+	// No matches: the API omits the results field
+	results, err = omgo.ParseGeocodeBody([]byte(`{"generationtime_ms": 0.2}`))
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
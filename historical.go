@@ -12,6 +12,7 @@ type HistoricalData struct {
 	Forecast   Forecast
 	HourlyData HourlyData
 	DailyData  DailyData
+	Stale      bool // Served from the cache after expiry, see StalePolicy
 }
 
 type HourlyData struct {
@@ -31,12 +32,12 @@ type HourlyData struct {
 	DiffuseRadiation       []float64
 	CloudCover             []float64
 	Visibility             []float64
-	WeatherCode            []int
+	WeatherCode            []WeatherCode
 }
 
 type DailyData struct {
 	Time                     []time.Time
-	WeatherCode              []int
+	WeatherCode              []WeatherCode
 	Temperature2mMax         []float64
 	Temperature2mMin         []float64
 	ApparentTemperatureMax   []float64
@@ -54,44 +55,92 @@ type DailyData struct {
 }
 
 func (c Client) GetHistoricalData(ctx context.Context, loc Location, opts *Options) (HistoricalData, error) {
-	if opts == nil {
-		return HistoricalData{}, ErrInvalidInput{Param: "options", Value: nil}
+	startDate, endDate, err := validateDateRange(opts)
+	if err != nil {
+		return HistoricalData{}, err
 	}
 
-	if opts.StartDate == "" || opts.EndDate == "" {
-		return HistoricalData{}, ErrInvalidInput{Param: "start_date or end_date", Value: "empty"}
+	body, stale, err := c.get(ctx, ArchiveEndpoint, loc, opts)
+	if err != nil {
+		return HistoricalData{}, fmt.Errorf("failed to get data: %w", err)
 	}
 
-	startDate, err := time.Parse("2006-01-02", opts.StartDate)
+	return parseHistoricalData(body, startDate, endDate, stale)
+}
+
+// GetHistoricalDataMany retrieves the historical data for multiple locations in as few API calls
+// as possible, see MaxBatchLocations. The data is returned in input order. When one of the calls
+// fails, the data fetched so far is returned along with the error and the rest is left empty
+func (c Client) GetHistoricalDataMany(ctx context.Context, locs []Location, opts *Options) ([]HistoricalData, error) {
+	startDate, endDate, err := validateDateRange(opts)
 	if err != nil {
-		return HistoricalData{}, ErrInvalidInput{Param: "start_date", Value: opts.StartDate}
+		return nil, err
 	}
 
-	endDate, err := time.Parse("2006-01-02", opts.EndDate)
-	if err != nil {
-		return HistoricalData{}, ErrInvalidInput{Param: "end_date", Value: opts.EndDate}
+	results, stale, fetchErr := c.getMany(ctx, ArchiveEndpoint, locs, opts)
+	if fetchErr != nil {
+		fetchErr = fmt.Errorf("failed to get data: %w", fetchErr)
+	}
+	if len(results) == 0 {
+		return nil, fetchErr
 	}
 
-	body, err := c.Get(ctx, loc, opts)
-	if err != nil {
-		return HistoricalData{}, fmt.Errorf("failed to get data: %w", err)
+	data := make([]HistoricalData, len(locs))
+	for i, body := range results {
+		data[i], err = parseHistoricalData(body, startDate, endDate, stale[i])
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	return data, fetchErr
+}
+
+// parseHistoricalData parses the archive response of a single location
+func parseHistoricalData(body []byte, startDate, endDate time.Time, stale bool) (HistoricalData, error) {
 	forecast, err := ParseBody(body)
 	if err != nil {
 		return HistoricalData{}, fmt.Errorf("failed to parse body: %w", err)
 	}
+	forecast.Stale = stale
 
 	historicalData, err := ParseHistoricalBody(body)
 	if err != nil {
 		return HistoricalData{}, fmt.Errorf("failed to parse historical body: %w", err)
 	}
 
+	// The dates are anchored at midnight in the timezone of the response, like DailyData.Time
+	loc := responseLocation(forecast.Timezone, forecast.TimezoneAbbreviation, forecast.UTCOffsetSeconds)
+
 	return HistoricalData{
-		StartDate:  startDate,
-		EndDate:    endDate,
+		StartDate:  inLocation(startDate, loc),
+		EndDate:    inLocation(endDate, loc),
 		Forecast:   *forecast,
 		HourlyData: historicalData.HourlyData,
 		DailyData:  historicalData.DailyData,
+		Stale:      stale,
 	}, nil
 }
+
+// validateDateRange checks the StartDate and EndDate options required by the archive style APIs
+func validateDateRange(opts *Options) (time.Time, time.Time, error) {
+	if opts == nil {
+		return time.Time{}, time.Time{}, ErrInvalidInput{Param: "options", Value: nil}
+	}
+
+	if opts.StartDate == "" || opts.EndDate == "" {
+		return time.Time{}, time.Time{}, ErrInvalidInput{Param: "start_date or end_date", Value: "empty"}
+	}
+
+	startDate, err := time.Parse("2006-01-02", opts.StartDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidInput{Param: "start_date", Value: opts.StartDate}
+	}
+
+	endDate, err := time.Parse("2006-01-02", opts.EndDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidInput{Param: "end_date", Value: opts.EndDate}
+	}
+
+	return startDate, endDate, nil
+}
@@ -0,0 +1,29 @@
+package omgo
+
+import (
+	"context"
+	"fmt"
+)
+
+// HistoricalForecast retrieves archived weather model forecasts for the provided location,
+// as opposed to GetHistoricalData which returns reanalysis data.
+//
+// StartDate and EndDate are required. The response is returned in the same shape as Forecast
+func (c Client) HistoricalForecast(ctx context.Context, loc Location, opts *Options) (*Forecast, error) {
+	if _, _, err := validateDateRange(opts); err != nil {
+		return nil, err
+	}
+
+	body, stale, err := c.get(ctx, HistoricalForecastEndpoint, loc, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get historical forecast data: %w", err)
+	}
+
+	fc, err := ParseBody(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse historical forecast data: %w", err)
+	}
+	fc.Stale = stale
+
+	return fc, nil
+}
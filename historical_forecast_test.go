@@ -0,0 +1,45 @@
+package omgo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoricalForecast(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	opts := &omgo.Options{
+		StartDate:     "2024-01-01",
+		EndDate:       "2024-01-07",
+		HourlyMetrics: []string{"temperature_2m"},
+		DailyMetrics:  []string{"temperature_2m_max"},
+	}
+
+	res, err := c.HistoricalForecast(context.Background(), loc, opts)
+	require.NoError(t, err)
+
+	require.Len(t, res.HourlyTimes, 7*24)
+	require.Len(t, res.HourlyMetrics["temperature_2m"], 7*24)
+	require.Len(t, res.DailyMetrics["temperature_2m_max"], 7)
+}
+
+func TestHistoricalForecast_InvalidDates(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	_, err = c.HistoricalForecast(context.Background(), loc, nil)
+	require.IsType(t, omgo.ErrInvalidInput{}, err)
+
+	_, err = c.HistoricalForecast(context.Background(), loc, &omgo.Options{StartDate: "2024-01-01", EndDate: "invalid"})
+	require.IsType(t, omgo.ErrInvalidInput{}, err)
+}
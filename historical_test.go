@@ -2,6 +2,8 @@ package omgo_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -85,3 +87,57 @@ func TestGetHistoricalData_NilOptions(t *testing.T) {
 	require.Error(t, err)
 	require.IsType(t, omgo.ErrInvalidInput{}, err)
 }
+
+func TestGetHistoricalDataMany(t *testing.T) {
+	calls := 0
+	srv := multiLocationServer(t, &calls)
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ArchiveEndpoint, srv.URL)
+
+	amsterdam, err := omgo.NewLocation(52.3738, 4.8910)
+	require.NoError(t, err)
+	paris, err := omgo.NewLocation(48.8566, 2.3522)
+	require.NoError(t, err)
+
+	opts := &omgo.Options{StartDate: "2024-01-01", EndDate: "2024-01-01", HourlyMetrics: []string{omgo.HourlyTemperature2m}}
+	data, err := c.GetHistoricalDataMany(context.Background(), []omgo.Location{amsterdam, paris}, opts)
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+	require.Len(t, data, 2)
+	require.Equal(t, 52.3738, data[0].Forecast.Latitude)
+	require.Equal(t, 48.8566, data[1].Forecast.Latitude)
+	require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), data[1].StartDate)
+	require.Equal(t, []float64{48.8566}, data[1].HourlyData.Temperature2m)
+
+	_, err = c.GetHistoricalDataMany(context.Background(), []omgo.Location{amsterdam}, nil)
+	require.Error(t, err)
+}
+
+func TestGetHistoricalData_ResponseTimezone(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"utc_offset_seconds": 7200,
+			"timezone": "Europe/Berlin",
+			"timezone_abbreviation": "CEST",
+			"daily": {"time": ["2024-07-01", "2024-07-02"], "temperature_2m_max": [24.1, 26.3]}
+		}`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ArchiveEndpoint, srv.URL)
+
+	loc, err := omgo.NewLocation(52.52, 13.41) // Berlin
+	require.NoError(t, err)
+
+	opts := &omgo.Options{StartDate: "2024-07-01", EndDate: "2024-07-02", Timezone: omgo.TimezoneAuto}
+	data, err := c.GetHistoricalData(context.Background(), loc, opts)
+	require.NoError(t, err)
+	require.True(t, data.StartDate.Equal(data.DailyData.Time[0]))
+	require.True(t, data.EndDate.Equal(data.DailyData.Time[1]))
+	require.Equal(t, data.DailyData.Time[0].Location(), data.StartDate.Location())
+}
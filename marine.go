@@ -0,0 +1,200 @@
+package omgo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MarineOptions holds the request options for the Marine API
+type MarineOptions struct {
+	LengthUnit    string        // Default "metric", alternatively "imperial"
+	Timezone      string        // Default "UTC"
+	PastDays      int           // Default 0
+	ForecastDays  int           // Default 7, up to 16
+	HourlyMetrics []string      // Lists required hourly metrics, see https://open-meteo.com/en/docs/marine-weather-api for valid metrics
+	DailyMetrics  []string      // Lists required daily metrics, see https://open-meteo.com/en/docs/marine-weather-api for valid metrics
+	StartDate     string        // Start date (format: YYYY-MM-DD)
+	EndDate       string        // End date (format: YYYY-MM-DD)
+	CacheTTL      time.Duration // Overrides the Client's TTLPolicy for this request, negative disables caching
+}
+
+// MarineForecast is the parsed response of the Marine API. All requested metrics are
+// available in HourlyMetrics/DailyMetrics, the common wave, swell and current metrics
+// are also exposed as typed series in Hourly and Daily
+type MarineForecast struct {
+	Latitude       float64
+	Longitude      float64
+	GenerationTime float64
+	HourlyUnits    map[string]string
+	HourlyMetrics  map[string][]float64
+	HourlyTimes    []time.Time
+	DailyUnits     map[string]string
+	DailyMetrics   map[string][]float64
+	DailyTimes     []time.Time
+	Hourly         MarineHourlyData
+	Daily          MarineDailyData
+	Stale          bool // Served from the cache after expiry, see StalePolicy
+}
+
+type MarineHourlyData struct {
+	Time                  []time.Time
+	WaveHeight            []float64
+	WaveDirection         []float64
+	WavePeriod            []float64
+	WindWaveHeight        []float64
+	WindWaveDirection     []float64
+	WindWavePeriod        []float64
+	WindWavePeakPeriod    []float64
+	SwellWaveHeight       []float64
+	SwellWaveDirection    []float64
+	SwellWavePeriod       []float64
+	SwellWavePeakPeriod   []float64
+	OceanCurrentVelocity  []float64
+	OceanCurrentDirection []float64
+	SeaSurfaceTemperature []float64
+}
+
+type MarineDailyData struct {
+	Time                       []time.Time
+	WaveHeightMax              []float64
+	WaveDirectionDominant      []float64
+	WavePeriodMax              []float64
+	WindWaveHeightMax          []float64
+	WindWaveDirectionDominant  []float64
+	WindWavePeriodMax          []float64
+	WindWavePeakPeriodMax      []float64
+	SwellWaveHeightMax         []float64
+	SwellWaveDirectionDominant []float64
+	SwellWavePeriodMax         []float64
+	SwellWavePeakPeriodMax     []float64
+}
+
+// Marine retrieves the marine weather forecast (waves, swell, ocean currents and sea
+// surface temperature) for the provided location.
+//
+// When no metrics are requested, wave height, direction and period are returned hourly
+func (c Client) Marine(ctx context.Context, loc Location, opts *MarineOptions) (*MarineForecast, error) {
+	// Work on a copy, so the defaults don't end up in the caller's options
+	reqOpts := MarineOptions{}
+	if opts != nil {
+		reqOpts = *opts
+	}
+	if len(reqOpts.HourlyMetrics) == 0 && len(reqOpts.DailyMetrics) == 0 {
+		reqOpts.HourlyMetrics = []string{"wave_height", "wave_direction", "wave_period"}
+	}
+
+	body, stale, err := c.send(ctx, request{Endpoint: MarineEndpoint, Query: queryFromMarineOptions(loc, &reqOpts), TTL: reqOpts.CacheTTL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get marine data: %w", err)
+	}
+
+	mf, err := ParseMarineBody(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse marine data: %w", err)
+	}
+
+	mf.Stale = stale
+
+	return mf, nil
+}
+
+func queryFromMarineOptions(loc Location, opts *MarineOptions) url.Values {
+	q := url.Values{}
+	setLocations(q, loc)
+
+	if opts.LengthUnit != "" {
+		q.Set("length_unit", opts.LengthUnit)
+	}
+	if opts.Timezone != "" {
+		q.Set("timezone", opts.Timezone)
+	}
+	if opts.PastDays != 0 {
+		q.Set("past_days", strconv.Itoa(opts.PastDays))
+	}
+	if opts.ForecastDays != 0 {
+		q.Set("forecast_days", strconv.Itoa(opts.ForecastDays))
+	}
+	if len(opts.HourlyMetrics) > 0 {
+		q.Set("hourly", strings.Join(opts.HourlyMetrics, ","))
+	}
+	if len(opts.DailyMetrics) > 0 {
+		q.Set("daily", strings.Join(opts.DailyMetrics, ","))
+	}
+	if opts.StartDate != "" {
+		q.Set("start_date", opts.StartDate)
+	}
+	if opts.EndDate != "" {
+		q.Set("end_date", opts.EndDate)
+	}
+
+	return q
+}
+
+// ParseMarineBody converts the Marine API response body into a MarineForecast struct
+func ParseMarineBody(body []byte) (*MarineForecast, error) {
+	f := &ForecastJSON{}
+	err := json.Unmarshal(body, f)
+	if err != nil {
+		return nil, err
+	}
+
+	mf := &MarineForecast{
+		Latitude:       f.Latitude,
+		Longitude:      f.Longitude,
+		GenerationTime: f.GenerationTime,
+		HourlyUnits:    f.HourlyUnits,
+		DailyUnits:     f.DailyUnits,
+	}
+
+	mf.HourlyTimes, mf.HourlyMetrics, err = parseHourlyMetrics(f.HourlyMetrics, f.location())
+	if err != nil {
+		return nil, err
+	}
+
+	mf.DailyTimes, mf.DailyMetrics, err = parseDailyMetrics(f.DailyMetrics, f.location())
+	if err != nil {
+		return nil, err
+	}
+
+	h := mf.HourlyMetrics
+	mf.Hourly = MarineHourlyData{
+		Time:                  mf.HourlyTimes,
+		WaveHeight:            h["wave_height"],
+		WaveDirection:         h["wave_direction"],
+		WavePeriod:            h["wave_period"],
+		WindWaveHeight:        h["wind_wave_height"],
+		WindWaveDirection:     h["wind_wave_direction"],
+		WindWavePeriod:        h["wind_wave_period"],
+		WindWavePeakPeriod:    h["wind_wave_peak_period"],
+		SwellWaveHeight:       h["swell_wave_height"],
+		SwellWaveDirection:    h["swell_wave_direction"],
+		SwellWavePeriod:       h["swell_wave_period"],
+		SwellWavePeakPeriod:   h["swell_wave_peak_period"],
+		OceanCurrentVelocity:  h["ocean_current_velocity"],
+		OceanCurrentDirection: h["ocean_current_direction"],
+		SeaSurfaceTemperature: h["sea_surface_temperature"],
+	}
+
+	d := mf.DailyMetrics
+	mf.Daily = MarineDailyData{
+		Time:                       mf.DailyTimes,
+		WaveHeightMax:              d["wave_height_max"],
+		WaveDirectionDominant:      d["wave_direction_dominant"],
+		WavePeriodMax:              d["wave_period_max"],
+		WindWaveHeightMax:          d["wind_wave_height_max"],
+		WindWaveDirectionDominant:  d["wind_wave_direction_dominant"],
+		WindWavePeriodMax:          d["wind_wave_period_max"],
+		WindWavePeakPeriodMax:      d["wind_wave_peak_period_max"],
+		SwellWaveHeightMax:         d["swell_wave_height_max"],
+		SwellWaveDirectionDominant: d["swell_wave_direction_dominant"],
+		SwellWavePeriodMax:         d["swell_wave_period_max"],
+		SwellWavePeakPeriodMax:     d["swell_wave_peak_period_max"],
+	}
+
+	return mf, nil
+}
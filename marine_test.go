@@ -0,0 +1,95 @@
+package omgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestMarine(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	loc, err := omgo.NewLocation(54.5, 10.0) // Baltic Sea, off Kiel
+	require.NoError(t, err)
+
+	opts := &omgo.MarineOptions{
+		HourlyMetrics: []string{"wave_height", "swell_wave_height"},
+		DailyMetrics:  []string{"wave_height_max"},
+	}
+
+	res, err := c.Marine(context.Background(), loc, opts)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, res.HourlyTimes)
+	require.Equal(t, len(res.HourlyTimes), len(res.Hourly.WaveHeight))
+	require.Equal(t, len(res.HourlyTimes), len(res.Hourly.SwellWaveHeight))
+	require.NotEmpty(t, res.DailyTimes)
+	require.Equal(t, len(res.DailyTimes), len(res.Daily.WaveHeightMax))
+}
+
+func TestParseMarineBody(t *testing.T) {
+	body := []byte(`{
+		"latitude": 54.541664,
+		"longitude": 10.208332,
+		"generationtime_ms": 0.5,
+		"hourly_units": {"time": "iso8601", "wave_height": "m", "ocean_current_velocity": "km/h"},
+		"hourly": {
+			"time": ["2024-09-10T00:00", "2024-09-10T01:00"],
+			"wave_height": [0.42, 0.46],
+			"ocean_current_velocity": [0.8, 1.1]
+		},
+		"daily": {
+			"time": ["2024-09-10"],
+			"wave_height_max": [0.7]
+		}
+	}`)
+
+	mf, err := omgo.ParseMarineBody(body)
+	require.NoError(t, err)
+	require.Equal(t, []float64{0.42, 0.46}, mf.Hourly.WaveHeight)
+	require.Equal(t, []float64{0.8, 1.1}, mf.Hourly.OceanCurrentVelocity)
+	require.Equal(t, "km/h", mf.HourlyUnits["ocean_current_velocity"])
+	require.Equal(t, time.Date(2024, time.September, 10, 1, 0, 0, 0, time.UTC), mf.Hourly.Time[1])
+	require.Equal(t, []float64{0.7}, mf.Daily.WaveHeightMax)
+	require.Equal(t, []time.Time{time.Date(2024, time.September, 10, 0, 0, 0, 0, time.UTC)}, mf.Daily.Time)
+	require.Nil(t, mf.Hourly.SeaSurfaceTemperature)
+}
+
+func TestMarine_SharedOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.MarineEndpoint, srv.URL)
+	c.RateLimiter = rate.NewLimiter(rate.Inf, 1)
+
+	// The options are shared between goroutines, the defaults must not be written into them
+	opts := &omgo.MarineOptions{}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			loc, err := omgo.NewLocation(54.544, 10.227+float64(i))
+			if err == nil {
+				_, err = c.Marine(context.Background(), loc, opts)
+			}
+			if err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.Nil(t, opts.HourlyMetrics)
+}
@@ -0,0 +1,280 @@
+package omgo
+
+import "fmt"
+
+// Hourly variables of the forecast and archive APIs, for Options.HourlyMetrics
+const (
+	HourlyTemperature2m                    = "temperature_2m"
+	HourlyRelativeHumidity2m               = "relative_humidity_2m"
+	HourlyDewPoint2m                       = "dew_point_2m"
+	HourlyApparentTemperature              = "apparent_temperature"
+	HourlyWetBulbTemperature2m             = "wet_bulb_temperature_2m"
+	HourlyPressureMSL                      = "pressure_msl"
+	HourlySurfacePressure                  = "surface_pressure"
+	HourlyCloudCover                       = "cloud_cover"
+	HourlyCloudCoverLow                    = "cloud_cover_low"
+	HourlyCloudCoverMid                    = "cloud_cover_mid"
+	HourlyCloudCoverHigh                   = "cloud_cover_high"
+	HourlyWindSpeed10m                     = "wind_speed_10m"
+	HourlyWindSpeed80m                     = "wind_speed_80m"
+	HourlyWindSpeed100m                    = "wind_speed_100m"
+	HourlyWindSpeed120m                    = "wind_speed_120m"
+	HourlyWindSpeed180m                    = "wind_speed_180m"
+	HourlyWindDirection10m                 = "wind_direction_10m"
+	HourlyWindDirection80m                 = "wind_direction_80m"
+	HourlyWindDirection100m                = "wind_direction_100m"
+	HourlyWindDirection120m                = "wind_direction_120m"
+	HourlyWindDirection180m                = "wind_direction_180m"
+	HourlyWindGusts10m                     = "wind_gusts_10m"
+	HourlyTemperature80m                   = "temperature_80m"
+	HourlyTemperature120m                  = "temperature_120m"
+	HourlyTemperature180m                  = "temperature_180m"
+	HourlyShortwaveRadiation               = "shortwave_radiation"
+	HourlyDirectRadiation                  = "direct_radiation"
+	HourlyDirectNormalIrradiance           = "direct_normal_irradiance"
+	HourlyDiffuseRadiation                 = "diffuse_radiation"
+	HourlyGlobalTiltedIrradiance           = "global_tilted_irradiance"
+	HourlyTerrestrialRadiation             = "terrestrial_radiation"
+	HourlyShortwaveRadiationInstant        = "shortwave_radiation_instant"
+	HourlyDirectRadiationInstant           = "direct_radiation_instant"
+	HourlyDirectNormalIrradianceInstant    = "direct_normal_irradiance_instant"
+	HourlyDiffuseRadiationInstant          = "diffuse_radiation_instant"
+	HourlyGlobalTiltedIrradianceInstant    = "global_tilted_irradiance_instant"
+	HourlyTerrestrialRadiationInstant      = "terrestrial_radiation_instant"
+	HourlyVapourPressureDeficit            = "vapour_pressure_deficit"
+	HourlyCAPE                             = "cape"
+	HourlyLiftedIndex                      = "lifted_index"
+	HourlyConvectiveInhibition             = "convective_inhibition"
+	HourlyEvapotranspiration               = "evapotranspiration"
+	HourlyET0FAOEvapotranspiration         = "et0_fao_evapotranspiration"
+	HourlyPrecipitation                    = "precipitation"
+	HourlyPrecipitationProbability         = "precipitation_probability"
+	HourlyRain                             = "rain"
+	HourlyShowers                          = "showers"
+	HourlySnowfall                         = "snowfall"
+	HourlySnowfallHeight                   = "snowfall_height"
+	HourlySnowDepth                        = "snow_depth"
+	HourlyFreezingLevelHeight              = "freezing_level_height"
+	HourlyBoundaryLayerHeight              = "boundary_layer_height"
+	HourlyTotalColumnIntegratedWaterVapour = "total_column_integrated_water_vapour"
+	HourlyLightningPotential               = "lightning_potential"
+	HourlyWeatherCode                      = "weather_code"
+	HourlyVisibility                       = "visibility"
+	HourlyIsDay                            = "is_day"
+	HourlySunshineDuration                 = "sunshine_duration"
+	HourlyUVIndex                          = "uv_index"
+	HourlyUVIndexClearSky                  = "uv_index_clear_sky"
+	HourlySoilTemperature0cm               = "soil_temperature_0cm"
+	HourlySoilTemperature6cm               = "soil_temperature_6cm"
+	HourlySoilTemperature18cm              = "soil_temperature_18cm"
+	HourlySoilTemperature54cm              = "soil_temperature_54cm"
+	HourlySoilMoisture0To1cm               = "soil_moisture_0_to_1cm"
+	HourlySoilMoisture1To3cm               = "soil_moisture_1_to_3cm"
+	HourlySoilMoisture3To9cm               = "soil_moisture_3_to_9cm"
+	HourlySoilMoisture9To27cm              = "soil_moisture_9_to_27cm"
+	HourlySoilMoisture27To81cm             = "soil_moisture_27_to_81cm"
+	HourlySoilTemperature0To7cm            = "soil_temperature_0_to_7cm"
+	HourlySoilTemperature7To28cm           = "soil_temperature_7_to_28cm"
+	HourlySoilTemperature28To100cm         = "soil_temperature_28_to_100cm"
+	HourlySoilTemperature100To255cm        = "soil_temperature_100_to_255cm"
+	HourlySoilMoisture0To7cm               = "soil_moisture_0_to_7cm"
+	HourlySoilMoisture7To28cm              = "soil_moisture_7_to_28cm"
+	HourlySoilMoisture28To100cm            = "soil_moisture_28_to_100cm"
+	HourlySoilMoisture100To255cm           = "soil_moisture_100_to_255cm"
+)
+
+// Daily aggregations of the forecast and archive APIs, for Options.DailyMetrics
+const (
+	DailyWeatherCode                  = "weather_code"
+	DailyTemperature2mMax             = "temperature_2m_max"
+	DailyTemperature2mMin             = "temperature_2m_min"
+	DailyTemperature2mMean            = "temperature_2m_mean"
+	DailyApparentTemperatureMax       = "apparent_temperature_max"
+	DailyApparentTemperatureMin       = "apparent_temperature_min"
+	DailyApparentTemperatureMean      = "apparent_temperature_mean"
+	DailySunrise                      = "sunrise"
+	DailySunset                       = "sunset"
+	DailyDaylightDuration             = "daylight_duration"
+	DailySunshineDuration             = "sunshine_duration"
+	DailyUVIndexMax                   = "uv_index_max"
+	DailyUVIndexClearSkyMax           = "uv_index_clear_sky_max"
+	DailyPrecipitationSum             = "precipitation_sum"
+	DailyRainSum                      = "rain_sum"
+	DailyShowersSum                   = "showers_sum"
+	DailySnowfallSum                  = "snowfall_sum"
+	DailyPrecipitationHours           = "precipitation_hours"
+	DailyPrecipitationProbabilityMax  = "precipitation_probability_max"
+	DailyPrecipitationProbabilityMin  = "precipitation_probability_min"
+	DailyPrecipitationProbabilityMean = "precipitation_probability_mean"
+	DailyWindSpeed10mMax              = "wind_speed_10m_max"
+	DailyWindGusts10mMax              = "wind_gusts_10m_max"
+	DailyWindDirection10mDominant     = "wind_direction_10m_dominant"
+	DailyShortwaveRadiationSum        = "shortwave_radiation_sum"
+	DailyET0FAOEvapotranspiration     = "et0_fao_evapotranspiration"
+)
+
+// Current conditions, for Options.CurrentMetrics. Any hourly variable is accepted as well
+const (
+	CurrentTemperature2m       = "temperature_2m"
+	CurrentRelativeHumidity2m  = "relative_humidity_2m"
+	CurrentApparentTemperature = "apparent_temperature"
+	CurrentIsDay               = "is_day"
+	CurrentPrecipitation       = "precipitation"
+	CurrentRain                = "rain"
+	CurrentShowers             = "showers"
+	CurrentSnowfall            = "snowfall"
+	CurrentWeatherCode         = "weather_code"
+	CurrentCloudCover          = "cloud_cover"
+	CurrentPressureMSL         = "pressure_msl"
+	CurrentSurfacePressure     = "surface_pressure"
+	CurrentWindSpeed10m        = "wind_speed_10m"
+	CurrentWindDirection10m    = "wind_direction_10m"
+	CurrentWindGusts10m        = "wind_gusts_10m"
+)
+
+// 15-minutely variables of the forecast API, for Options.Minutely15Metrics
+const (
+	Minutely15Temperature2m                 = "temperature_2m"
+	Minutely15RelativeHumidity2m            = "relative_humidity_2m"
+	Minutely15DewPoint2m                    = "dew_point_2m"
+	Minutely15ApparentTemperature           = "apparent_temperature"
+	Minutely15Precipitation                 = "precipitation"
+	Minutely15Rain                          = "rain"
+	Minutely15Snowfall                      = "snowfall"
+	Minutely15SnowfallHeight                = "snowfall_height"
+	Minutely15FreezingLevelHeight           = "freezing_level_height"
+	Minutely15SunshineDuration              = "sunshine_duration"
+	Minutely15WeatherCode                   = "weather_code"
+	Minutely15WindSpeed10m                  = "wind_speed_10m"
+	Minutely15WindSpeed80m                  = "wind_speed_80m"
+	Minutely15WindDirection10m              = "wind_direction_10m"
+	Minutely15WindDirection80m              = "wind_direction_80m"
+	Minutely15WindGusts10m                  = "wind_gusts_10m"
+	Minutely15Visibility                    = "visibility"
+	Minutely15CAPE                          = "cape"
+	Minutely15LightningPotential            = "lightning_potential"
+	Minutely15IsDay                         = "is_day"
+	Minutely15ShortwaveRadiation            = "shortwave_radiation"
+	Minutely15DirectRadiation               = "direct_radiation"
+	Minutely15DiffuseRadiation              = "diffuse_radiation"
+	Minutely15DirectNormalIrradiance        = "direct_normal_irradiance"
+	Minutely15GlobalTiltedIrradiance        = "global_tilted_irradiance"
+	Minutely15TerrestrialRadiation          = "terrestrial_radiation"
+	Minutely15ShortwaveRadiationInstant     = "shortwave_radiation_instant"
+	Minutely15DirectRadiationInstant        = "direct_radiation_instant"
+	Minutely15DiffuseRadiationInstant       = "diffuse_radiation_instant"
+	Minutely15DirectNormalIrradianceInstant = "direct_normal_irradiance_instant"
+	Minutely15GlobalTiltedIrradianceInstant = "global_tilted_irradiance_instant"
+	Minutely15TerrestrialRadiationInstant   = "terrestrial_radiation_instant"
+)
+
+// Variables available on pressure levels, see PressureLevelVariable
+const (
+	PressureTemperature        = "temperature"
+	PressureRelativeHumidity   = "relative_humidity"
+	PressureDewPoint           = "dew_point"
+	PressureCloudCover         = "cloud_cover"
+	PressureWindSpeed          = "wind_speed"
+	PressureWindDirection      = "wind_direction"
+	PressureGeopotentialHeight = "geopotential_height"
+	PressureVerticalVelocity   = "vertical_velocity"
+)
+
+// PressureLevels lists the pressure levels (hPa) the forecast API provides
+var PressureLevels = []int{1000, 975, 950, 925, 900, 850, 800, 700, 600, 500, 400, 300, 250, 200, 150, 100, 70, 50, 30}
+
+// PressureLevelVariable returns the hourly variable of a pressure level variable at the given
+// level, e.g. PressureLevelVariable(PressureTemperature, 850) returns "temperature_850hPa"
+func PressureLevelVariable(variable string, hPa int) string {
+	return fmt.Sprintf("%s_%dhPa", variable, hPa)
+}
+
+// Unit values for Options.TemperatureUnit, WindspeedUnit and PrecipitationUnit
+const (
+	TemperatureUnitCelsius    = "celsius"
+	TemperatureUnitFahrenheit = "fahrenheit"
+
+	WindspeedUnitKmh   = "kmh"
+	WindspeedUnitMs    = "ms"
+	WindspeedUnitMph   = "mph"
+	WindspeedUnitKnots = "kn"
+
+	PrecipitationUnitMm   = "mm"
+	PrecipitationUnitInch = "inch"
+)
+
+// TimezoneAuto lets the API resolve the timezone of the location, for Options.Timezone. The
+// resolved zone is reported in Forecast.Timezone
+const TimezoneAuto = "auto"
+
+var (
+	hourlyVariables = newVariableSet(
+		HourlyTemperature2m, HourlyRelativeHumidity2m, HourlyDewPoint2m, HourlyApparentTemperature,
+		HourlyWetBulbTemperature2m, HourlyPressureMSL, HourlySurfacePressure, HourlyCloudCover,
+		HourlyCloudCoverLow, HourlyCloudCoverMid, HourlyCloudCoverHigh, HourlyWindSpeed10m,
+		HourlyWindSpeed80m, HourlyWindSpeed100m, HourlyWindSpeed120m, HourlyWindSpeed180m,
+		HourlyWindDirection10m, HourlyWindDirection80m, HourlyWindDirection100m, HourlyWindDirection120m,
+		HourlyWindDirection180m, HourlyWindGusts10m, HourlyTemperature80m, HourlyTemperature120m,
+		HourlyTemperature180m, HourlyShortwaveRadiation, HourlyDirectRadiation, HourlyDirectNormalIrradiance,
+		HourlyDiffuseRadiation, HourlyGlobalTiltedIrradiance, HourlyTerrestrialRadiation, HourlyShortwaveRadiationInstant,
+		HourlyDirectRadiationInstant, HourlyDirectNormalIrradianceInstant, HourlyDiffuseRadiationInstant, HourlyGlobalTiltedIrradianceInstant,
+		HourlyTerrestrialRadiationInstant, HourlyVapourPressureDeficit, HourlyCAPE, HourlyLiftedIndex,
+		HourlyConvectiveInhibition, HourlyEvapotranspiration, HourlyET0FAOEvapotranspiration, HourlyPrecipitation,
+		HourlyPrecipitationProbability, HourlyRain, HourlyShowers, HourlySnowfall,
+		HourlySnowfallHeight, HourlySnowDepth, HourlyFreezingLevelHeight, HourlyBoundaryLayerHeight,
+		HourlyTotalColumnIntegratedWaterVapour, HourlyLightningPotential, HourlyWeatherCode, HourlyVisibility,
+		HourlyIsDay, HourlySunshineDuration, HourlyUVIndex, HourlyUVIndexClearSky,
+		HourlySoilTemperature0cm, HourlySoilTemperature6cm, HourlySoilTemperature18cm, HourlySoilTemperature54cm,
+		HourlySoilMoisture0To1cm, HourlySoilMoisture1To3cm, HourlySoilMoisture3To9cm, HourlySoilMoisture9To27cm,
+		HourlySoilMoisture27To81cm, HourlySoilTemperature0To7cm, HourlySoilTemperature7To28cm, HourlySoilTemperature28To100cm,
+		HourlySoilTemperature100To255cm, HourlySoilMoisture0To7cm, HourlySoilMoisture7To28cm, HourlySoilMoisture28To100cm,
+		HourlySoilMoisture100To255cm,
+	)
+
+	dailyVariables = newVariableSet(
+		DailyWeatherCode, DailyTemperature2mMax, DailyTemperature2mMin, DailyTemperature2mMean,
+		DailyApparentTemperatureMax, DailyApparentTemperatureMin, DailyApparentTemperatureMean, DailySunrise,
+		DailySunset, DailyDaylightDuration, DailySunshineDuration, DailyUVIndexMax,
+		DailyUVIndexClearSkyMax, DailyPrecipitationSum, DailyRainSum, DailyShowersSum,
+		DailySnowfallSum, DailyPrecipitationHours, DailyPrecipitationProbabilityMax, DailyPrecipitationProbabilityMin,
+		DailyPrecipitationProbabilityMean, DailyWindSpeed10mMax, DailyWindGusts10mMax, DailyWindDirection10mDominant,
+		DailyShortwaveRadiationSum, DailyET0FAOEvapotranspiration,
+	)
+
+	currentVariables = newVariableSet(
+		CurrentTemperature2m, CurrentRelativeHumidity2m, CurrentApparentTemperature, CurrentIsDay,
+		CurrentPrecipitation, CurrentRain, CurrentShowers, CurrentSnowfall,
+		CurrentWeatherCode, CurrentCloudCover, CurrentPressureMSL, CurrentSurfacePressure,
+		CurrentWindSpeed10m, CurrentWindDirection10m, CurrentWindGusts10m,
+	)
+
+	minutely15Variables = newVariableSet(
+		Minutely15Temperature2m, Minutely15RelativeHumidity2m, Minutely15DewPoint2m, Minutely15ApparentTemperature,
+		Minutely15Precipitation, Minutely15Rain, Minutely15Snowfall, Minutely15SnowfallHeight,
+		Minutely15FreezingLevelHeight, Minutely15SunshineDuration, Minutely15WeatherCode, Minutely15WindSpeed10m,
+		Minutely15WindSpeed80m, Minutely15WindDirection10m, Minutely15WindDirection80m, Minutely15WindGusts10m,
+		Minutely15Visibility, Minutely15CAPE, Minutely15LightningPotential, Minutely15IsDay,
+		Minutely15ShortwaveRadiation, Minutely15DirectRadiation, Minutely15DiffuseRadiation, Minutely15DirectNormalIrradiance,
+		Minutely15GlobalTiltedIrradiance, Minutely15TerrestrialRadiation, Minutely15ShortwaveRadiationInstant, Minutely15DirectRadiationInstant,
+		Minutely15DiffuseRadiationInstant, Minutely15DirectNormalIrradianceInstant, Minutely15GlobalTiltedIrradianceInstant, Minutely15TerrestrialRadiationInstant,
+	)
+
+	pressureVariables = newVariableSet(
+		PressureTemperature, PressureRelativeHumidity, PressureDewPoint, PressureCloudCover,
+		PressureWindSpeed, PressureWindDirection, PressureGeopotentialHeight, PressureVerticalVelocity,
+	)
+
+	temperatureUnits   = newVariableSet(TemperatureUnitCelsius, TemperatureUnitFahrenheit)
+	windspeedUnits     = newVariableSet(WindspeedUnitKmh, WindspeedUnitMs, WindspeedUnitMph, WindspeedUnitKnots)
+	precipitationUnits = newVariableSet(PrecipitationUnitMm, PrecipitationUnitInch)
+)
+
+type variableSet map[string]bool
+
+func newVariableSet(names ...string) variableSet {
+	s := make(variableSet, len(names))
+	for _, n := range names {
+		s[n] = true
+	}
+	return s
+}
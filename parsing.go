@@ -3,42 +3,66 @@ package omgo
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type ForecastJSON struct {
-	Latitude       float64
-	Longitude      float64
-	Elevation      float64
-	GenerationTime float64                    `json:"generationtime_ms"`
-	CurrentWeather CurrentWeather             `json:"current_weather"`
-	HourlyUnits    map[string]string          `json:"hourly_units"`
-	HourlyMetrics  map[string]json.RawMessage `json:"hourly"` // Parsed later, the API returns both Time and floats here
-	DailyUnits     map[string]string          `json:"daily_units"`
-	DailyMetrics   map[string]json.RawMessage `json:"daily"` // Parsed later, the API returns both Time and floats here
+	Latitude             float64
+	Longitude            float64
+	Elevation            float64
+	GenerationTime       float64                    `json:"generationtime_ms"`
+	UTCOffsetSeconds     int                        `json:"utc_offset_seconds"`
+	Timezone             string                     `json:"timezone"`
+	TimezoneAbbreviation string                     `json:"timezone_abbreviation"`
+	CurrentWeather       CurrentWeather             `json:"current_weather"`
+	HourlyUnits          map[string]string          `json:"hourly_units"`
+	HourlyMetrics        map[string]json.RawMessage `json:"hourly"` // Parsed later, the API returns both Time and floats here
+	DailyUnits           map[string]string          `json:"daily_units"`
+	DailyMetrics         map[string]json.RawMessage `json:"daily"` // Parsed later, the API returns both Time and floats here
+	CurrentUnits         map[string]string          `json:"current_units"`
+	CurrentMetrics       map[string]json.RawMessage `json:"current"` // Parsed later, the API returns both Time and floats here
+	Minutely15Units      map[string]string          `json:"minutely_15_units"`
+	Minutely15Metrics    map[string]json.RawMessage `json:"minutely_15"` // Parsed later, the API returns both Time and floats here
+}
 
+// location returns the location of the times in the response
+func (f *ForecastJSON) location() *time.Location {
+	return responseLocation(f.Timezone, f.TimezoneAbbreviation, f.UTCOffsetSeconds)
 }
 
 type Forecast struct {
-	Latitude       float64
-	Longitude      float64
-	Elevation      float64
-	GenerationTime float64
-	CurrentWeather CurrentWeather
-	HourlyUnits    map[string]string
-	HourlyMetrics  map[string][]float64 // Parsed from ForecastJSON.HourlyMetrics
-	HourlyTimes    []time.Time          // Parsed from ForecastJSON.HourlyMetrics
-	DailyUnits     map[string]string
-	DailyMetrics   map[string][]float64 // Parsed from ForecastJSON.DailyMetrics
-	DailyTimes     []time.Time          // Parsed from ForecastJSON.DailyMetrics
+	Latitude             float64
+	Longitude            float64
+	Elevation            float64
+	GenerationTime       float64
+	UTCOffsetSeconds     int    // Offset of the times in the response, they carry the matching *time.Location
+	Timezone             string // e.g. "Europe/Berlin", resolved by the API for Options.Timezone TimezoneAuto
+	TimezoneAbbreviation string // e.g. "CEST"
+	CurrentWeather       CurrentWeather
+	HourlyUnits          map[string]string
+	HourlyMetrics        map[string][]float64 // Parsed from ForecastJSON.HourlyMetrics
+	HourlyTimes          []time.Time          // Parsed from ForecastJSON.HourlyMetrics
+	DailyUnits           map[string]string
+	DailyMetrics         map[string][]float64 // Parsed from ForecastJSON.DailyMetrics
+	DailyTimes           []time.Time          // Parsed from ForecastJSON.DailyMetrics
+	CurrentUnits         map[string]string
+	CurrentMetrics       map[string]float64 // Parsed from ForecastJSON.CurrentMetrics
+	CurrentTime          time.Time          // Parsed from ForecastJSON.CurrentMetrics
+	Minutely15Units      map[string]string
+	Minutely15Metrics    map[string][]float64 // Parsed from ForecastJSON.Minutely15Metrics
+	Minutely15Times      []time.Time          // Parsed from ForecastJSON.Minutely15Metrics
+	Stale                bool                 // Served from the cache after expiry, see StalePolicy
 }
 
 type CurrentWeather struct {
 	Temperature   float64
 	Time          ApiTime
-	WeatherCode   float64
+	WeatherCode   WeatherCode
 	WindDirection float64
 	WindSpeed     float64
+	Stale         bool `json:"-"` // Served from the cache after expiry, see StalePolicy
 }
 
 // ParseBody converts the API response body into a Forecast struct
@@ -52,31 +76,91 @@ func ParseBody(body []byte) (*Forecast, error) {
 	}
 
 	fc := &Forecast{
-		Latitude:       f.Latitude,
-		Longitude:      f.Longitude,
-		Elevation:      f.Elevation,
-		GenerationTime: f.GenerationTime,
-		CurrentWeather: f.CurrentWeather,
-		HourlyUnits:    f.HourlyUnits,
-		HourlyTimes:    []time.Time{},
-		HourlyMetrics:  make(map[string][]float64),
-		DailyUnits:     f.DailyUnits,
-		DailyTimes:     []time.Time{},
-		DailyMetrics:   make(map[string][]float64),
-	}
-
-	for k, v := range f.HourlyMetrics {
+		Latitude:             f.Latitude,
+		Longitude:            f.Longitude,
+		Elevation:            f.Elevation,
+		GenerationTime:       f.GenerationTime,
+		CurrentWeather:       f.CurrentWeather,
+		HourlyUnits:          f.HourlyUnits,
+		DailyUnits:           f.DailyUnits,
+		CurrentUnits:         f.CurrentUnits,
+		Minutely15Units:      f.Minutely15Units,
+		UTCOffsetSeconds:     f.UTCOffsetSeconds,
+		Timezone:             f.Timezone,
+		TimezoneAbbreviation: f.TimezoneAbbreviation,
+	}
+
+	loc := f.location()
+	fc.CurrentWeather.Time.Time = inLocation(fc.CurrentWeather.Time.Time, loc)
+
+	fc.HourlyTimes, fc.HourlyMetrics, err = parseHourlyMetrics(f.HourlyMetrics, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	fc.DailyTimes, fc.DailyMetrics, err = parseDailyMetrics(f.DailyMetrics, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	fc.CurrentTime, fc.CurrentMetrics, err = parseCurrentMetrics(f.CurrentMetrics, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	// 15-minutely timestamps share the format of the hourly ones
+	fc.Minutely15Times, fc.Minutely15Metrics, err = parseHourlyMetrics(f.Minutely15Metrics, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	return fc, nil
+}
+
+// parseCurrentMetrics splits a raw "current" block into its timestamp and values. The
+// "interval" of the values in seconds is dropped
+func parseCurrentMetrics(raw map[string]json.RawMessage, loc *time.Location) (time.Time, map[string]float64, error) {
+	var t time.Time
+	metrics := make(map[string]float64)
+
+	for k, v := range raw {
+		switch k {
+		case "time":
+			target := ApiTime{}
+			if err := json.Unmarshal(v, &target); err != nil {
+				return time.Time{}, nil, err
+			}
+			t = inLocation(target.Time, loc)
+		case "interval":
+		default:
+			var target float64
+			if err := json.Unmarshal(v, &target); err != nil {
+				return time.Time{}, nil, err
+			}
+			metrics[k] = target
+		}
+	}
+
+	return t, metrics, nil
+}
+
+// parseHourlyMetrics splits a raw "hourly" block into its timestamps in loc and float series
+func parseHourlyMetrics(raw map[string]json.RawMessage, loc *time.Location) ([]time.Time, map[string][]float64, error) {
+	times := []time.Time{}
+	metrics := make(map[string][]float64)
+
+	for k, v := range raw {
 		if k == "time" {
 			// We unmarshal into an ApiTime array because of the custom formatting
 			// of the timestamp in the API response
 			target := []ApiTime{}
 			err := json.Unmarshal(v, &target)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			for _, at := range target {
-				fc.HourlyTimes = append(fc.HourlyTimes, at.Time)
+				times = append(times, inLocation(at.Time, loc))
 			}
 
 			continue
@@ -84,23 +168,31 @@ func ParseBody(body []byte) (*Forecast, error) {
 		target := []float64{}
 		err := json.Unmarshal(v, &target)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		fc.HourlyMetrics[k] = target
+		metrics[k] = target
 	}
 
-	for k, v := range f.DailyMetrics {
+	return times, metrics, nil
+}
+
+// parseDailyMetrics splits a raw "daily" block into its dates, at midnight in loc, and float series
+func parseDailyMetrics(raw map[string]json.RawMessage, loc *time.Location) ([]time.Time, map[string][]float64, error) {
+	times := []time.Time{}
+	metrics := make(map[string][]float64)
+
+	for k, v := range raw {
 		if k == "time" {
-			// We unmarshal into an ApiTime array because of the custom formatting
-			// of the timestamp in the API response
+			// We unmarshal into an ApiDate array because of the custom formatting
+			// of the date in the API response
 			target := []ApiDate{}
 			err := json.Unmarshal(v, &target)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			for _, at := range target {
-				fc.DailyTimes = append(fc.DailyTimes, at.Time)
+				times = append(times, inLocation(at.Time, loc))
 			}
 
 			continue
@@ -108,52 +200,80 @@ func ParseBody(body []byte) (*Forecast, error) {
 		target := []float64{}
 		err := json.Unmarshal(v, &target)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		fc.DailyMetrics[k] = target
+		metrics[k] = target
 	}
 
-	return fc, nil
+	return times, metrics, nil
+}
+
+// splitMemberKey splits an ensemble metric key such as "temperature_2m_member07" into
+// its base metric and member number. Keys without a member suffix return ok == false
+func splitMemberKey(key string) (metric string, member int, ok bool) {
+	return splitNumberedKey(key, "_member")
+}
+
+// splitPreviousDayKey splits a previous runs metric key such as "temperature_2m_previous_day3"
+// into its base metric and lead day. Keys without a lead day suffix return ok == false
+func splitPreviousDayKey(key string) (metric string, day int, ok bool) {
+	return splitNumberedKey(key, "_previous_day")
+}
+
+func splitNumberedKey(key string, marker string) (string, int, bool) {
+	i := strings.LastIndex(key, marker)
+	if i < 0 {
+		return key, 0, false
+	}
+	n, err := strconv.Atoi(key[i+len(marker):])
+	if err != nil || n < 0 {
+		return key, 0, false
+	}
+	return key[:i], n, true
 }
 
 func ParseHistoricalBody(body []byte) (HistoricalData, error) {
 	var data struct {
+		UTCOffsetSeconds     int    `json:"utc_offset_seconds"`
+		Timezone             string `json:"timezone"`
+		TimezoneAbbreviation string `json:"timezone_abbreviation"`
+
 		Hourly struct {
-			Time                   []string  `json:"time"`
-			Temperature2m          []float64 `json:"temperature_2m"`
-			RelativeHumidity2m     []float64 `json:"relative_humidity_2m"`
-			DewPoint2m             []float64 `json:"dew_point_2m"`
-			ApparentTemperature    []float64 `json:"apparent_temperature"`
-			Precipitation          []float64 `json:"precipitation"`
-			Rain                   []float64 `json:"rain"`
-			Snowfall               []float64 `json:"snowfall"`
-			WindSpeed10m           []float64 `json:"wind_speed_10m"`
-			WindDirection10m       []float64 `json:"wind_direction_10m"`
-			WindGusts10m           []float64 `json:"wind_gusts_10m"`
-			ShortwaveRadiation     []float64 `json:"shortwave_radiation"`
-			DirectNormalIrradiance []float64 `json:"direct_normal_irradiance"`
-			DiffuseRadiation       []float64 `json:"diffuse_radiation"`
-			CloudCover             []float64 `json:"cloud_cover"`
-			Visibility             []float64 `json:"visibility"`
-			WeatherCode            []int     `json:"weather_code"`
+			Time                   []string      `json:"time"`
+			Temperature2m          []float64     `json:"temperature_2m"`
+			RelativeHumidity2m     []float64     `json:"relative_humidity_2m"`
+			DewPoint2m             []float64     `json:"dew_point_2m"`
+			ApparentTemperature    []float64     `json:"apparent_temperature"`
+			Precipitation          []float64     `json:"precipitation"`
+			Rain                   []float64     `json:"rain"`
+			Snowfall               []float64     `json:"snowfall"`
+			WindSpeed10m           []float64     `json:"wind_speed_10m"`
+			WindDirection10m       []float64     `json:"wind_direction_10m"`
+			WindGusts10m           []float64     `json:"wind_gusts_10m"`
+			ShortwaveRadiation     []float64     `json:"shortwave_radiation"`
+			DirectNormalIrradiance []float64     `json:"direct_normal_irradiance"`
+			DiffuseRadiation       []float64     `json:"diffuse_radiation"`
+			CloudCover             []float64     `json:"cloud_cover"`
+			Visibility             []float64     `json:"visibility"`
+			WeatherCode            []WeatherCode `json:"weather_code"`
 		} `json:"hourly"`
 		Daily struct {
-			Time                     []string  `json:"time"`
-			WeatherCode              []int     `json:"weather_code"`
-			Temperature2mMax         []float64 `json:"temperature_2m_max"`
-			Temperature2mMin         []float64 `json:"temperature_2m_min"`
-			ApparentTemperatureMax   []float64 `json:"apparent_temperature_max"`
-			ApparentTemperatureMin   []float64 `json:"apparent_temperature_min"`
-			Sunrise                  []string  `json:"sunrise"`
-			Sunset                   []string  `json:"sunset"`
-			PrecipitationSum         []float64 `json:"precipitation_sum"`
-			RainSum                  []float64 `json:"rain_sum"`
-			SnowfallSum              []float64 `json:"snowfall_sum"`
-			PrecipitationHours       []float64 `json:"precipitation_hours"`
-			WindSpeed10mMax          []float64 `json:"wind_speed_10m_max"`
-			WindGusts10mMax          []float64 `json:"wind_gusts_10m_max"`
-			WindDirection10mDominant []float64 `json:"wind_direction_10m_dominant"`
-			ShortwaveRadiationSum    []float64 `json:"shortwave_radiation_sum"`
+			Time                     []string      `json:"time"`
+			WeatherCode              []WeatherCode `json:"weather_code"`
+			Temperature2mMax         []float64     `json:"temperature_2m_max"`
+			Temperature2mMin         []float64     `json:"temperature_2m_min"`
+			ApparentTemperatureMax   []float64     `json:"apparent_temperature_max"`
+			ApparentTemperatureMin   []float64     `json:"apparent_temperature_min"`
+			Sunrise                  []string      `json:"sunrise"`
+			Sunset                   []string      `json:"sunset"`
+			PrecipitationSum         []float64     `json:"precipitation_sum"`
+			RainSum                  []float64     `json:"rain_sum"`
+			SnowfallSum              []float64     `json:"snowfall_sum"`
+			PrecipitationHours       []float64     `json:"precipitation_hours"`
+			WindSpeed10mMax          []float64     `json:"wind_speed_10m_max"`
+			WindGusts10mMax          []float64     `json:"wind_gusts_10m_max"`
+			WindDirection10mDominant []float64     `json:"wind_direction_10m_dominant"`
+			ShortwaveRadiationSum    []float64     `json:"shortwave_radiation_sum"`
 		} `json:"daily"`
 	}
 
@@ -161,6 +281,7 @@ func ParseHistoricalBody(body []byte) (HistoricalData, error) {
 	if err != nil {
 		return HistoricalData{}, err
 	}
+	loc := responseLocation(data.Timezone, data.TimezoneAbbreviation, data.UTCOffsetSeconds)
 
 	historicalData := HistoricalData{
 		HourlyData: HourlyData{
@@ -203,7 +324,7 @@ func ParseHistoricalBody(body []byte) (HistoricalData, error) {
 	}
 
 	for i, timeStr := range data.Hourly.Time {
-		t, err := time.Parse("2006-01-02T15:04", timeStr)
+		t, err := time.ParseInLocation("2006-01-02T15:04", timeStr, loc)
 		if err != nil {
 			return HistoricalData{}, fmt.Errorf("failed to parse hourly time: %w", err)
 		}
@@ -211,7 +332,7 @@ func ParseHistoricalBody(body []byte) (HistoricalData, error) {
 	}
 
 	for i, timeStr := range data.Daily.Time {
-		t, err := time.Parse("2006-01-02", timeStr)
+		t, err := time.ParseInLocation("2006-01-02", timeStr, loc)
 		if err != nil {
 			return HistoricalData{}, fmt.Errorf("failed to parse daily time: %w", err)
 		}
@@ -219,7 +340,7 @@ func ParseHistoricalBody(body []byte) (HistoricalData, error) {
 	}
 
 	for i, timeStr := range data.Daily.Sunrise {
-		t, err := time.Parse("2006-01-02T15:04", timeStr)
+		t, err := time.ParseInLocation("2006-01-02T15:04", timeStr, loc)
 		if err != nil {
 			return HistoricalData{}, fmt.Errorf("failed to parse sunrise time: %w", err)
 		}
@@ -227,7 +348,7 @@ func ParseHistoricalBody(body []byte) (HistoricalData, error) {
 	}
 
 	for i, timeStr := range data.Daily.Sunset {
-		t, err := time.Parse("2006-01-02T15:04", timeStr)
+		t, err := time.ParseInLocation("2006-01-02T15:04", timeStr, loc)
 		if err != nil {
 			return HistoricalData{}, fmt.Errorf("failed to parse sunset time: %w", err)
 		}
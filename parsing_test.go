@@ -32,6 +32,7 @@ func TestForecastUnmarshalWithHourlyValues(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []float64{13, 12.7, 12.7}, fc.HourlyMetrics["temperature_2m"])
 	require.Equal(t, float64(262), fc.CurrentWeather.WindDirection)
+	require.Equal(t, WeatherCodeOvercast, fc.CurrentWeather.WeatherCode)
 	require.Equal(t,
 		[]time.Time{
 			time.Date(2021, time.August, 28, 0, 0, 0, 0, time.UTC),
@@ -92,14 +93,89 @@ func TestForecastUnmarshalWithDailyValues(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []float64{11.4, 11.1, 11.6, 11, 10.8, 10.5, 10.5, 10.5, 10.8, 11.3, 12.2}, fc.HourlyMetrics["temperature_2m"])
 	require.Equal(t, []float64{14.1, 12.9, 14.8, 15.1, 15, 17.3, 18.5}, fc.DailyMetrics["apparent_temperature_max"])
+	zone := time.FixedZone("", 7200)
 	require.Equal(t,
 		[]time.Time{
-			time.Date(2021, time.September, 20, 0, 0, 0, 0, time.UTC),
-			time.Date(2021, time.September, 21, 0, 0, 0, 0, time.UTC),
-			time.Date(2021, time.September, 22, 0, 0, 0, 0, time.UTC),
-			time.Date(2021, time.September, 23, 0, 0, 0, 0, time.UTC),
-			time.Date(2021, time.September, 24, 0, 0, 0, 0, time.UTC),
-			time.Date(2021, time.September, 25, 0, 0, 0, 0, time.UTC),
-			time.Date(2021, time.September, 26, 0, 0, 0, 0, time.UTC)},
+			time.Date(2021, time.September, 20, 0, 0, 0, 0, zone),
+			time.Date(2021, time.September, 21, 0, 0, 0, 0, zone),
+			time.Date(2021, time.September, 22, 0, 0, 0, 0, zone),
+			time.Date(2021, time.September, 23, 0, 0, 0, 0, zone),
+			time.Date(2021, time.September, 24, 0, 0, 0, 0, zone),
+			time.Date(2021, time.September, 25, 0, 0, 0, 0, zone),
+			time.Date(2021, time.September, 26, 0, 0, 0, 0, zone)},
 		fc.DailyTimes)
 }
+
+func TestHistoricalUnmarshalWeatherCodes(t *testing.T) {
+	body := []byte(`{
+		"hourly": {"time": ["2021-08-28T00:00", "2021-08-28T01:00"], "weather_code": [3.0, 61]},
+		"daily": {"time": ["2021-08-28"], "weather_code": [95]}
+	}`)
+
+	data, err := ParseHistoricalBody(body)
+	require.NoError(t, err)
+	require.Equal(t, []WeatherCode{WeatherCodeOvercast, WeatherCodeSlightRain}, data.HourlyData.WeatherCode)
+	require.Equal(t, []WeatherCode{WeatherCodeThunderstorm}, data.DailyData.WeatherCode)
+}
+
+func TestForecastUnmarshalWithCurrentAndMinutely15Values(t *testing.T) {
+	body := []byte(`{
+		"current_units": {"time": "iso8601", "interval": "seconds", "temperature_2m": "°C", "is_day": ""},
+		"current": {"time": "2021-08-28T09:00", "interval": 900, "temperature_2m": 13.3, "is_day": 1},
+		"minutely_15_units": {"precipitation": "mm"},
+		"minutely_15": {"time": ["2021-08-28T09:00", "2021-08-28T09:15"], "precipitation": [0.1, 0.3]}
+	}`)
+
+	fc, err := ParseBody(body)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2021, time.August, 28, 9, 0, 0, 0, time.UTC), fc.CurrentTime)
+	require.Equal(t, map[string]float64{"temperature_2m": 13.3, "is_day": 1}, fc.CurrentMetrics)
+	require.Equal(t, "°C", fc.CurrentUnits["temperature_2m"])
+	require.Equal(t, []float64{0.1, 0.3}, fc.Minutely15Metrics["precipitation"])
+	require.Equal(t,
+		[]time.Time{
+			time.Date(2021, time.August, 28, 9, 0, 0, 0, time.UTC),
+			time.Date(2021, time.August, 28, 9, 15, 0, 0, time.UTC)},
+		fc.Minutely15Times)
+}
+
+func TestForecastUnmarshalInResponseTimezone(t *testing.T) {
+	body := []byte(`{
+		"utc_offset_seconds": 7200,
+		"timezone": "Europe/Berlin",
+		"timezone_abbreviation": "CEST",
+		"hourly": {"time": ["2021-08-28T00:00", "2021-08-28T01:00"], "temperature_2m": [13, 12.7]},
+		"daily": {"time": ["2021-08-28"], "temperature_2m_max": [21.3]},
+		"current_weather": {"time": "2021-08-28T09:00", "temperature": 13.3}
+	}`)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	fc, err := ParseBody(body)
+	require.NoError(t, err)
+	require.Equal(t, 7200, fc.UTCOffsetSeconds)
+	require.Equal(t, "Europe/Berlin", fc.Timezone)
+	require.Equal(t, "CEST", fc.TimezoneAbbreviation)
+
+	require.Equal(t, berlin, fc.HourlyTimes[0].Location())
+	require.True(t, fc.HourlyTimes[0].Equal(time.Date(2021, time.August, 27, 22, 0, 0, 0, time.UTC)))
+	require.True(t, fc.DailyTimes[0].Equal(time.Date(2021, time.August, 27, 22, 0, 0, 0, time.UTC)))
+	require.Equal(t, berlin, fc.CurrentWeather.Time.Location())
+	require.Equal(t, 9, fc.CurrentWeather.Time.Hour())
+
+	data, err := ParseHistoricalBody(body)
+	require.NoError(t, err)
+	require.Equal(t, fc.HourlyTimes, data.HourlyData.Time)
+	require.Equal(t, fc.DailyTimes, data.DailyData.Time)
+}
+
+func TestResponseLocation(t *testing.T) {
+	require.Equal(t, time.UTC, responseLocation("", "", 0))
+	require.Equal(t, time.UTC, responseLocation("GMT", "GMT", 0))
+
+	loc := responseLocation("Nowhere/Unknown", "XYZ", -3600)
+	name, offset := time.Date(2021, time.August, 28, 0, 0, 0, 0, loc).Zone()
+	require.Equal(t, "XYZ", name)
+	require.Equal(t, -3600, offset)
+}
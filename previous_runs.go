@@ -0,0 +1,107 @@
+package omgo
+
+import (
+	"context"
+	"fmt"
+)
+
+// MaxPreviousDay is the oldest model run, in days before the forecast time, served by the
+// Previous Runs API
+const MaxPreviousDay = 7
+
+// PreviousRunsForecast is the parsed response of the Previous Runs API.
+//
+// Forecast holds the most recent run. HourlyLeadDays and DailyLeadDays hold the metrics as
+// predicted 1 to 7 days earlier, keyed by lead day and then by the plain metric name. Lead
+// day 0 refers to the most recent run as well
+type PreviousRunsForecast struct {
+	Forecast       Forecast
+	HourlyLeadDays map[int]map[string][]float64
+	DailyLeadDays  map[int]map[string][]float64
+	Stale          bool // Served from the cache after expiry, see StalePolicy
+}
+
+// PreviousRuns retrieves the forecasts of previous model runs for the provided location.
+//
+// For every requested hourly and daily metric the "_previous_dayN" variants for the given
+// lead days are requested as well. Without lead days all of 1 to MaxPreviousDay are
+// requested. StartDate and EndDate are required
+func (c Client) PreviousRuns(ctx context.Context, loc Location, opts *Options, leadDays []int) (*PreviousRunsForecast, error) {
+	if _, _, err := validateDateRange(opts); err != nil {
+		return nil, err
+	}
+
+	if len(leadDays) == 0 {
+		for day := 1; day <= MaxPreviousDay; day++ {
+			leadDays = append(leadDays, day)
+		}
+	}
+	for _, day := range leadDays {
+		if day < 1 || day > MaxPreviousDay {
+			return nil, ErrInvalidInput{Param: "lead_day", Value: day}
+		}
+	}
+
+	// Work on a copy, so the caller's metrics are not extended with the lead day variants
+	reqOpts := *opts
+	reqOpts.HourlyMetrics = withPreviousDays(opts.HourlyMetrics, leadDays)
+	reqOpts.DailyMetrics = withPreviousDays(opts.DailyMetrics, leadDays)
+
+	body, stale, err := c.get(ctx, PreviousRunsEndpoint, loc, &reqOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous runs data: %w", err)
+	}
+
+	pr, err := ParsePreviousRunsBody(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse previous runs data: %w", err)
+	}
+	pr.Forecast.Stale = stale
+	pr.Stale = stale
+
+	return pr, nil
+}
+
+func withPreviousDays(metrics []string, leadDays []int) []string {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(metrics)*(len(leadDays)+1))
+	result = append(result, metrics...)
+	for _, day := range leadDays {
+		for _, m := range metrics {
+			result = append(result, fmt.Sprintf("%s_previous_day%d", m, day))
+		}
+	}
+	return result
+}
+
+// ParsePreviousRunsBody converts the Previous Runs API response body into a
+// PreviousRunsForecast, grouping the "_previous_dayN" suffixed metrics per lead day
+func ParsePreviousRunsBody(body []byte) (*PreviousRunsForecast, error) {
+	fc, err := ParseBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &PreviousRunsForecast{
+		Forecast:       *fc,
+		HourlyLeadDays: groupByLeadDay(fc.HourlyMetrics),
+		DailyLeadDays:  groupByLeadDay(fc.DailyMetrics),
+	}
+
+	return pr, nil
+}
+
+func groupByLeadDay(metrics map[string][]float64) map[int]map[string][]float64 {
+	result := make(map[int]map[string][]float64)
+	for k, v := range metrics {
+		metric, day, _ := splitPreviousDayKey(k)
+		if result[day] == nil {
+			result[day] = make(map[string][]float64)
+		}
+		result[day][metric] = v
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package omgo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviousRuns(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	opts := &omgo.Options{
+		StartDate:     "2024-06-01",
+		EndDate:       "2024-06-02",
+		HourlyMetrics: []string{"temperature_2m"},
+	}
+
+	res, err := c.PreviousRuns(context.Background(), loc, opts, []int{1, 3})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"temperature_2m"}, opts.HourlyMetrics)
+	require.Len(t, res.HourlyLeadDays[0]["temperature_2m"], 48)
+	require.Len(t, res.HourlyLeadDays[1]["temperature_2m"], 48)
+	require.Len(t, res.HourlyLeadDays[3]["temperature_2m"], 48)
+	require.NotContains(t, res.HourlyLeadDays, 2)
+}
+
+func TestPreviousRuns_InvalidOptions(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	_, err = c.PreviousRuns(context.Background(), loc, nil, nil)
+	require.IsType(t, omgo.ErrInvalidInput{}, err)
+
+	opts := &omgo.Options{StartDate: "2024-06-01", EndDate: "2024-06-02"}
+	_, err = c.PreviousRuns(context.Background(), loc, opts, []int{8})
+	require.IsType(t, omgo.ErrInvalidInput{}, err)
+}
+
+func TestParsePreviousRunsBody(t *testing.T) {
+	body := []byte(`{
+		"latitude": 52.38,
+		"longitude": 4.9,
+		"hourly": {
+			"time": ["2024-06-01T00:00", "2024-06-01T01:00"],
+			"temperature_2m": [12.1, 11.8],
+			"temperature_2m_previous_day1": [12.4, 12.0],
+			"temperature_2m_previous_day7": [10.2, 9.9]
+		},
+		"daily": {
+			"time": ["2024-06-01"],
+			"temperature_2m_max": [19.5],
+			"temperature_2m_max_previous_day1": [20.1]
+		}
+	}`)
+
+	pr, err := omgo.ParsePreviousRunsBody(body)
+	require.NoError(t, err)
+	require.Equal(t, []float64{12.1, 11.8}, pr.HourlyLeadDays[0]["temperature_2m"])
+	require.Equal(t, []float64{12.4, 12.0}, pr.HourlyLeadDays[1]["temperature_2m"])
+	require.Equal(t, []float64{10.2, 9.9}, pr.HourlyLeadDays[7]["temperature_2m"])
+	require.Equal(t, []float64{20.1}, pr.DailyLeadDays[1]["temperature_2m_max"])
+	require.Len(t, pr.Forecast.HourlyTimes, 2)
+}
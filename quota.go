@@ -0,0 +1,216 @@
+package omgo
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// QuotaLimit limits the call weight spent within a window. Windows start at multiples of the
+// period in UTC, e.g. at the start of every minute, hour or day
+type QuotaLimit struct {
+	Period time.Duration
+	Limit  float64
+	// CalendarMonth makes the windows follow calendar months in UTC, as the monthly quotas of
+	// commercial plans do, instead of fixed periods. Period is ignored when set
+	CalendarMonth bool
+}
+
+// FreeTierLimits returns the limits of the free Open-Meteo API: 600 calls per minute,
+// 5,000 per hour and 10,000 per day
+func FreeTierLimits() []QuotaLimit {
+	return []QuotaLimit{
+		{Period: time.Minute, Limit: 600},
+		{Period: time.Hour, Limit: 5000},
+		{Period: 24 * time.Hour, Limit: 10000},
+	}
+}
+
+// QuotaUsage reports the state of a single quota window
+type QuotaUsage struct {
+	Period        time.Duration
+	CalendarMonth bool
+	Limit         float64
+	Used          float64
+	Remaining     float64
+	ResetAt       time.Time // Start of the next window
+}
+
+// Quota tracks the call weight spent across several windows, and blocks or fails requests that
+// would exceed one of the limits. Requests served from the cache are not counted.
+//
+// Quotas are opt-in, set Client.Quota e.g. to NewQuota(FreeTierLimits()...) for the free API.
+// Commercial plans have their own limits, see QuotaLimit.CalendarMonth
+type Quota struct {
+	Wait bool // Wait for the window to reset instead of returning ErrQuotaExceeded
+
+	mu      sync.Mutex
+	windows []quotaWindow
+}
+
+type quotaWindow struct {
+	QuotaLimit
+	start time.Time
+	used  float64
+}
+
+// NewQuota creates a quota tracker enforcing all given limits
+func NewQuota(limits ...QuotaLimit) *Quota {
+	q := &Quota{}
+	for _, l := range limits {
+		q.windows = append(q.windows, quotaWindow{QuotaLimit: l})
+	}
+	return q
+}
+
+// Reserve spends the given call weight. When a limit would be exceeded it returns
+// ErrQuotaExceeded, or waits for the window to reset if Wait is set. Calls weighing more than
+// a whole window are only let through at the start of an unused window, which they drain
+func (q *Quota) Reserve(ctx context.Context, weight float64) error {
+	for {
+		wait, err := q.reserve(weight, time.Now())
+		if err == nil || !q.Wait || wait <= 0 {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve spends the weight if all windows allow it. Otherwise it returns the error of the
+// first exceeded window along with the wait until that window resets
+func (q *Quota) reserve(weight float64, now time.Time) (time.Duration, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i := range q.windows {
+		w := &q.windows[i]
+		w.advance(now)
+
+		if w.used+weight <= w.Limit || w.used == 0 {
+			continue
+		}
+
+		err := ErrQuotaExceeded{
+			Period:        w.Period,
+			CalendarMonth: w.CalendarMonth,
+			Limit:         w.Limit,
+			Remaining:     math.Max(w.Limit-w.used, 0),
+			Weight:        weight,
+			ResetAt:       w.end(),
+		}
+		return w.end().Sub(now), err
+	}
+
+	for i := range q.windows {
+		q.windows[i].used += weight
+	}
+	return 0, nil
+}
+
+// Remaining reports the usage of every window, e.g. to schedule requests around the limits
+func (q *Quota) Remaining() []QuotaUsage {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	usage := make([]QuotaUsage, 0, len(q.windows))
+	for i := range q.windows {
+		w := &q.windows[i]
+		w.advance(now)
+		usage = append(usage, QuotaUsage{
+			Period:        w.Period,
+			CalendarMonth: w.CalendarMonth,
+			Limit:         w.Limit,
+			Used:          w.used,
+			Remaining:     math.Max(w.Limit-w.used, 0),
+			ResetAt:       w.end(),
+		})
+	}
+	return usage
+}
+
+// advance moves the window to the one containing now, resetting the used weight
+func (w *quotaWindow) advance(now time.Time) {
+	now = now.UTC()
+
+	var start time.Time
+	if w.CalendarMonth {
+		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	} else {
+		start = now.Truncate(w.Period)
+	}
+
+	if !start.Equal(w.start) {
+		w.start = start
+		w.used = 0
+	}
+}
+
+func (w *quotaWindow) end() time.Time {
+	if w.CalendarMonth {
+		return w.start.AddDate(0, 1, 0)
+	}
+	return w.start.Add(w.Period)
+}
+
+// CallWeight returns how many API calls a request counts as. Open-Meteo counts requests for
+// more than 10 variables or more than 2 weeks of data as multiple calls, for every location
+func CallWeight(variables, days, locations int) float64 {
+	return math.Max(float64(variables)/10, 1) * math.Max(float64(days)/14, 1) * math.Max(float64(locations), 1)
+}
+
+// defaultForecastDays is the number of days the forecast APIs return without forecast_days
+const defaultForecastDays = 7
+
+// daysPerForecastMonth approximates the days covered by every month of forecast_months
+const daysPerForecastMonth = 30
+
+// urlCallWeight derives the call weight of a request from its query parameters
+func urlCallWeight(rawURL string) float64 {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return 1
+	}
+	q := u.Query()
+
+	variables := 0
+	for _, param := range []string{"hourly", "daily", "current", "minutely_15"} {
+		if v := q.Get(param); v != "" {
+			variables += len(strings.Split(v, ","))
+		}
+	}
+
+	days := defaultForecastDays
+	if n, err := strconv.Atoi(q.Get("forecast_days")); err == nil {
+		days = n
+	}
+	if n, err := strconv.Atoi(q.Get("forecast_hours")); err == nil {
+		days = (n + 23) / 24
+	}
+	if n, err := strconv.Atoi(q.Get("forecast_months")); err == nil {
+		days = n * daysPerForecastMonth
+	}
+	if n, err := strconv.Atoi(q.Get("past_days")); err == nil {
+		days += n
+	}
+	start, startErr := time.Parse("2006-01-02", q.Get("start_date"))
+	end, endErr := time.Parse("2006-01-02", q.Get("end_date"))
+	if startErr == nil && endErr == nil {
+		days = int(end.Sub(start).Hours()/24) + 1
+	}
+
+	locations := len(strings.Split(q.Get("latitude"), ","))
+
+	return CallWeight(variables, days, locations)
+}
@@ -0,0 +1,149 @@
+package omgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallWeight(t *testing.T) {
+	require.Equal(t, 1.0, omgo.CallWeight(0, 0, 0))
+	require.Equal(t, 1.0, omgo.CallWeight(10, 14, 1))
+	require.Equal(t, 2.0, omgo.CallWeight(20, 7, 1))
+	require.Equal(t, 3.0, omgo.CallWeight(15, 28, 1))
+	require.Equal(t, 4.0, omgo.CallWeight(5, 7, 4))
+}
+
+func TestQuotaExceeded(t *testing.T) {
+	q := omgo.NewQuota(omgo.QuotaLimit{Period: time.Hour, Limit: 3}, omgo.QuotaLimit{CalendarMonth: true, Limit: 100})
+
+	require.NoError(t, q.Reserve(context.Background(), 2))
+	require.NoError(t, q.Reserve(context.Background(), 1))
+
+	err := q.Reserve(context.Background(), 1)
+	var quotaErr omgo.ErrQuotaExceeded
+	require.True(t, errors.As(err, &quotaErr))
+	require.Equal(t, time.Hour, quotaErr.Period)
+	require.Equal(t, 0.0, quotaErr.Remaining)
+
+	usage := q.Remaining()
+	require.Len(t, usage, 2)
+	require.Equal(t, 3.0, usage[0].Used)
+	require.Equal(t, 0.0, usage[0].Remaining)
+	require.Equal(t, 97.0, usage[1].Remaining)
+	require.Equal(t, 1, usage[1].ResetAt.Day())
+	require.True(t, usage[1].ResetAt.After(time.Now()))
+}
+
+func TestQuotaWait(t *testing.T) {
+	q := omgo.NewQuota(omgo.QuotaLimit{Period: 100 * time.Millisecond, Limit: 1})
+	q.Wait = true
+
+	require.NoError(t, q.Reserve(context.Background(), 1))
+	start := time.Now()
+	require.NoError(t, q.Reserve(context.Background(), 1))
+	require.Greater(t, time.Since(start), time.Duration(0))
+
+	// Calls weighing more than the limit wait for an unused window, and drain it
+	require.NoError(t, q.Reserve(context.Background(), 3))
+	require.Equal(t, 0.0, q.Remaining()[0].Remaining)
+
+	q = omgo.NewQuota(omgo.QuotaLimit{Period: time.Hour, Limit: 1})
+	q.Wait = true
+	require.NoError(t, q.Reserve(context.Background(), 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, q.Reserve(ctx, 1), context.DeadlineExceeded)
+}
+
+func TestQuotaHeavierThanWindow(t *testing.T) {
+	q := omgo.NewQuota(omgo.FreeTierLimits()...)
+
+	// A multi-decade climate projection weighs more than the minutely limit
+	require.NoError(t, q.Reserve(context.Background(), 2635))
+
+	err := q.Reserve(context.Background(), 1)
+	var quotaErr omgo.ErrQuotaExceeded
+	require.True(t, errors.As(err, &quotaErr))
+	require.Equal(t, time.Minute, quotaErr.Period)
+	require.Equal(t, 0.0, quotaErr.Remaining)
+}
+
+func TestClientQuotaDisabledByDefault(t *testing.T) {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	require.Nil(t, c.Quota)
+}
+
+func TestClientQuota(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+	c.Quota = omgo.NewQuota(omgo.QuotaLimit{Period: time.Hour, Limit: 3})
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	// 20 variables count as 2 calls
+	metrics := make([]string, 20)
+	for i := range metrics {
+		metrics[i] = "temperature_2m"
+	}
+	_, err = c.Forecast(context.Background(), loc, &omgo.Options{HourlyMetrics: metrics})
+	require.NoError(t, err)
+	require.Equal(t, 1.0, c.Quota.Remaining()[0].Remaining)
+
+	// Cached responses are free
+	_, err = c.Forecast(context.Background(), loc, &omgo.Options{HourlyMetrics: metrics})
+	require.NoError(t, err)
+	require.Equal(t, 1.0, c.Quota.Remaining()[0].Remaining)
+
+	_, err = c.Forecast(context.Background(), loc, &omgo.Options{HourlyMetrics: metrics, PastDays: 1})
+	require.True(t, errors.As(err, &omgo.ErrQuotaExceeded{}))
+	require.Equal(t, 1, calls)
+}
+
+func TestQuotaFixedThirtyDays(t *testing.T) {
+	// A 30 day period is a fixed window, not a calendar month
+	q := omgo.NewQuota(omgo.QuotaLimit{Period: 30 * 24 * time.Hour, Limit: 10})
+	require.NoError(t, q.Reserve(context.Background(), 1))
+
+	usage := q.Remaining()[0]
+	require.False(t, usage.CalendarMonth)
+	require.True(t, usage.ResetAt.Equal(usage.ResetAt.Truncate(30*24*time.Hour)))
+	require.LessOrEqual(t, time.Until(usage.ResetAt), 30*24*time.Hour)
+}
+
+func TestClientQuotaForecastMonths(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+	c.Quota = omgo.NewQuota(omgo.QuotaLimit{Period: time.Hour, Limit: 100})
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	// 6 months count as 180 days
+	_, err = c.Forecast(context.Background(), loc, &omgo.Options{DailyMetrics: []string{"temperature_2m_max"}, SeasonalForecast: true, ForecastMonths: 6})
+	require.NoError(t, err)
+	require.InDelta(t, 100-180.0/14, c.Quota.Remaining()[0].Remaining, 1e-9)
+}
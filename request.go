@@ -0,0 +1,123 @@
+package omgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// request describes an API request independently of the base URL of its endpoint, which is
+// looked up in the Client's endpoint table when the request is sent
+type request struct {
+	Endpoint Endpoint
+	Query    url.Values
+	TTL      time.Duration // 0 follows the Client's TTLPolicy, negative disables caching
+}
+
+// send performs the request, see getURL. The returned bool reports whether the body was served stale
+func (c *Client) send(ctx context.Context, r request) ([]byte, bool, error) {
+	return c.getURL(ctx, r.Endpoint, c.EndpointURL(r.Endpoint)+"?"+r.Query.Encode(), r.TTL)
+}
+
+// MaxBatchLocations is the number of locations sent per call by the multi-location methods such
+// as ForecastMany. Larger inputs are split into multiple calls automatically
+const MaxBatchLocations = 100
+
+// sendMany sends the query built for every chunk of at most MaxBatchLocations locations. It returns
+// the raw result and whether it was served stale for every location, in input order. When a chunk
+// fails, the results of the preceding chunks are returned along with the error. With a Quota that
+// Waits, chunks block until the limits allow them
+func (c *Client) sendMany(ctx context.Context, e Endpoint, locs []Location, ttl time.Duration,
+	query func(chunk []Location) url.Values) ([]json.RawMessage, []bool, error) {
+	results := make([]json.RawMessage, 0, len(locs))
+	stale := make([]bool, 0, len(locs))
+	err := c.sendChunked(ctx, e, locs, MaxBatchLocations, ttl, query, func(body []byte, n int, chunkStale bool) error {
+		chunkResults, err := splitBody(body, n)
+		if err != nil {
+			return err
+		}
+
+		results = append(results, chunkResults...)
+		for i := 0; i < n; i++ {
+			stale = append(stale, chunkStale)
+		}
+		return nil
+	})
+
+	return results, stale, err
+}
+
+// sendChunked sends the query built for every chunk of at most size locations, and passes every
+// response to collect along with the number of locations in its chunk and whether it was served
+// stale. Chunks are sent in input order, and it stops at the first chunk that fails to be sent
+// or collected, so everything collected before the error is complete
+func (c *Client) sendChunked(ctx context.Context, e Endpoint, locs []Location, size int, ttl time.Duration,
+	query func(chunk []Location) url.Values, collect func(body []byte, n int, stale bool) error) error {
+	if len(locs) == 0 {
+		return ErrInvalidInput{Param: "locations", Value: "empty"}
+	}
+
+	for start := 0; start < len(locs); start += size {
+		end := start + size
+		if end > len(locs) {
+			end = len(locs)
+		}
+		chunk := locs[start:end]
+
+		body, stale, err := c.send(ctx, request{Endpoint: e, Query: query(chunk), TTL: ttl})
+		if err != nil {
+			return err
+		}
+		if err := collect(body, len(chunk), stale); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitBody splits the response of a multi-location request into the results per location. The
+// API returns a JSON array for multiple locations, and a single object for a single location
+func splitBody(body []byte, n int) ([]json.RawMessage, error) {
+	body = bytes.TrimSpace(body)
+
+	var results []json.RawMessage
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &results); err != nil {
+			return nil, err
+		}
+	} else {
+		results = []json.RawMessage{body}
+	}
+
+	if len(results) != n {
+		return nil, ErrAPIResponse{StatusCode: 0, Message: fmt.Sprintf("expected %d results, got %d", n, len(results))}
+	}
+	return results, nil
+}
+
+// setLocations sets the latitude and longitude parameters, comma separated for multiple locations
+func setLocations(q url.Values, locs ...Location) {
+	if len(locs) == 0 {
+		return
+	}
+
+	lats := make([]string, len(locs))
+	lons := make([]string, len(locs))
+	for i, loc := range locs {
+		lats[i] = formatFloat(loc.lat)
+		lons[i] = formatFloat(loc.lon)
+	}
+	q.Set("latitude", strings.Join(lats, ","))
+	q.Set("longitude", strings.Join(lons, ","))
+}
+
+// formatFloat formats f with the fewest digits that parse back to the same value
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,93 @@
+package omgo
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how Client.Get retries failed requests. Retries wait with an
+// exponential backoff, or as long as the API asks for through the Retry-After header.
+// No retry is attempted when the wait would exceed the context deadline
+type RetryPolicy struct {
+	MaxAttempts     int              // Total number of attempts, including the first one. 0 or 1 disables retries
+	BaseBackoff     time.Duration    // Wait before the first retry, doubled for every next retry
+	MaxBackoff      time.Duration    // Upper bound of the wait between attempts, 0 for no bound
+	Jitter          float64          // Fraction (0-1) of the backoff that is randomised
+	RetryableStatus []int            // HTTP status codes that are retried
+	RetryableError  func(error) bool // Decides whether a transport error is retried, defaults to IsTransportError
+}
+
+// DefaultRetryPolicy returns the retry policy used by NewClient: up to 3 attempts for
+// network errors, 429 and 5xx responses
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:     3,
+		BaseBackoff:     500 * time.Millisecond,
+		MaxBackoff:      10 * time.Second,
+		Jitter:          0.2,
+		RetryableStatus: []int{429, 500, 502, 503, 504},
+	}
+}
+
+// IsTransportError reports whether err is a network level error of the HTTP client,
+// excluding cancelled or timed out contexts
+func IsTransportError(err error) bool {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+func (p RetryPolicy) retryable(statusCode int, err error) bool {
+	if statusCode == 0 {
+		if p.RetryableError != nil {
+			return p.RetryableError(err)
+		}
+		return IsTransportError(err)
+	}
+
+	for _, s := range p.RetryableStatus {
+		if s == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the wait before the next attempt, after the given number of failed attempts
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(p.Jitter * rand.Float64() * float64(d))
+	}
+	return d
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as an HTTP date
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(header); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
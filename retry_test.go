@@ -0,0 +1,123 @@
+package omgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+)
+
+func newRetryTestClient(t *testing.T, handler http.HandlerFunc) (omgo.Client, omgo.Location, func()) {
+	srv := httptest.NewServer(handler)
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+	c.Retry.BaseBackoff = time.Millisecond
+	c.Retry.Jitter = 0
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	return c, loc, srv.Close
+}
+
+func TestRetry_ServerErrors(t *testing.T) {
+	var calls int32
+	c, loc, done := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+	defer done()
+
+	_, err := c.Get(context.Background(), loc, nil)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	c, loc, done := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer done()
+
+	_, err := c.Get(context.Background(), loc, nil)
+	require.IsType(t, omgo.ErrRateLimit{}, err)
+	require.Equal(t, int32(c.Retry.MaxAttempts), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_NotRetryable(t *testing.T) {
+	var calls int32
+	c, loc, done := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": true, "reason": "Latitude must be in range of -90 to 90°. Given: 1000.0."}`))
+	})
+	defer done()
+
+	_, err := c.Get(context.Background(), loc, nil)
+	require.IsType(t, omgo.ErrAPIReason{}, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_RetryAfter(t *testing.T) {
+	var calls int32
+	c, loc, done := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+	defer done()
+
+	start := time.Now()
+	_, err := c.Get(context.Background(), loc, nil)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_RespectsDeadline(t *testing.T) {
+	var calls int32
+	c, loc, done := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.Get(ctx, loc, nil)
+	require.IsType(t, omgo.ErrAPIResponse{}, err)
+	require.Less(t, time.Since(start), time.Second)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_Disabled(t *testing.T) {
+	var calls int32
+	c, loc, done := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer done()
+	c.Retry = omgo.RetryPolicy{}
+
+	_, err := c.Get(context.Background(), loc, nil)
+	require.Error(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -11,6 +11,7 @@ type SatelliteData struct {
 	Infrared     float64 `json:"infrared"`
 	VisibleLight float64 `json:"visible_light"`
 	WaterVapor   float64 `json:"water_vapor"`
+	Stale        bool    `json:"-"` // Served from the cache after expiry, see StalePolicy
 }
 
 func (c Client) GetSatelliteData(ctx context.Context, loc Location, opts *Options) (SatelliteData, error) {
@@ -21,7 +22,7 @@ func (c Client) GetSatelliteData(ctx context.Context, loc Location, opts *Option
 		opts.SatelliteMetrics = []string{"cloud_cover", "infrared", "visible_light", "water_vapor"}
 	}
 
-	body, err := c.Get(ctx, loc, opts)
+	body, stale, err := c.get(ctx, SatelliteEndpoint, loc, opts)
 	if err != nil {
 		return SatelliteData{}, fmt.Errorf("failed to get satellite data: %w", err)
 	}
@@ -30,6 +31,7 @@ func (c Client) GetSatelliteData(ctx context.Context, loc Location, opts *Option
 	if err != nil {
 		return SatelliteData{}, fmt.Errorf("failed to parse satellite data: %w", err)
 	}
+	satData.Stale = stale
 
 	return satData, nil
 }
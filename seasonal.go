@@ -9,6 +9,7 @@ type SeasonalForecast struct {
 	StartDate time.Time
 	EndDate   time.Time
 	Forecast  Forecast
+	Stale     bool // Served from the cache after expiry, see StalePolicy
 }
 
 func (c Client) GetSeasonalForecast(ctx context.Context, loc Location, opts *Options) (SeasonalForecast, error) {
@@ -24,7 +25,7 @@ func (c Client) GetSeasonalForecast(ctx context.Context, loc Location, opts *Opt
 		return SeasonalForecast{}, ErrInvalidInput{Param: "ForecastMonths", Value: opts.ForecastMonths}
 	}
 
-	body, err := c.Get(ctx, loc, opts)
+	body, stale, err := c.get(ctx, SeasonalEndpoint, loc, opts)
 	if err != nil {
 		return SeasonalForecast{}, err
 	}
@@ -33,6 +34,7 @@ func (c Client) GetSeasonalForecast(ctx context.Context, loc Location, opts *Opt
 	if err != nil {
 		return SeasonalForecast{}, err
 	}
+	forecast.Stale = stale
 
 	startDate := time.Now()
 	endDate := startDate.AddDate(0, opts.ForecastMonths, 0)
@@ -41,5 +43,6 @@ func (c Client) GetSeasonalForecast(ctx context.Context, loc Location, opts *Opt
 		StartDate: startDate,
 		EndDate:   endDate,
 		Forecast:  *forecast,
+		Stale:     stale,
 	}, nil
 }
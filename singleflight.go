@@ -0,0 +1,110 @@
+package omgo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// flightGroup deduplicates concurrent requests for the same key: the first caller starts the
+// request, later callers wait for its result. The request runs detached from the callers'
+// contexts, so one caller giving up does not fail the others. It is cancelled once every
+// waiting caller has given up
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	waiters map[*flightWaiter]struct{}
+	cancel  context.CancelFunc
+}
+
+type flightWaiter struct {
+	deadline    time.Time
+	hasDeadline bool
+}
+
+// flightContext is the context of a shared request. Its deadline is the latest deadline of
+// the waiting callers, so retries are only attempted when at least one caller can still use
+// the result
+type flightContext struct {
+	context.Context
+	group *flightGroup
+	call  *flightCall
+}
+
+func (f flightContext) Deadline() (time.Time, bool) {
+	f.group.mu.Lock()
+	defer f.group.mu.Unlock()
+
+	var latest time.Time
+	for w := range f.call.waiters {
+		if !w.hasDeadline {
+			return time.Time{}, false
+		}
+		if w.deadline.After(latest) {
+			latest = w.deadline
+		}
+	}
+	return latest, !latest.IsZero()
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: make(map[string]*flightCall)}
+}
+
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	w := &flightWaiter{}
+	w.deadline, w.hasDeadline = ctx.Deadline()
+
+	g.mu.Lock()
+	call, found := g.calls[key]
+	if !found {
+		fctx, cancel := context.WithCancel(context.Background())
+		call = &flightCall{
+			done:    make(chan struct{}),
+			waiters: make(map[*flightWaiter]struct{}),
+			cancel:  cancel,
+		}
+		g.calls[key] = call
+
+		go func() {
+			call.body, call.err = fn(flightContext{Context: fctx, group: g, call: call})
+			cancel()
+
+			g.mu.Lock()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	call.waiters[w] = struct{}{}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.body, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		delete(call.waiters, w)
+		if len(call.waiters) == 0 {
+			call.cancel()
+			// New callers should start a new request instead of joining the cancelled one
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,113 @@
+package omgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+)
+
+func newSlowTestClient(t *testing.T, delay time.Duration) (omgo.Client, *int32, func()) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		select {
+		case <-time.After(delay):
+			_, _ = w.Write([]byte(`{"latitude": 52.37}`))
+		case <-r.Context().Done():
+		}
+	}))
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+
+	return c, &calls, srv.Close
+}
+
+func TestConcurrentRequestsAreCoalesced(t *testing.T) {
+	c, calls, done := newSlowTestClient(t, 100*time.Millisecond)
+	defer done()
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fc, err := c.Forecast(context.Background(), loc, nil)
+			if err == nil && fc.Latitude != 52.37 {
+				err = omgo.ErrAPIResponse{Message: "unexpected body"}
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestCoalescedRequestSurvivesCancelledCaller(t *testing.T) {
+	c, calls, done := newSlowTestClient(t, 200*time.Millisecond)
+	defer done()
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelledErr := make(chan error, 1)
+	go func() {
+		_, err := c.Get(ctx, loc, nil)
+		cancelledErr <- err
+	}()
+
+	// Let the first caller start the request, then join it and cancel the first caller
+	time.Sleep(20 * time.Millisecond)
+	result := make(chan error, 1)
+	go func() {
+		_, err := c.Get(context.Background(), loc, nil)
+		result <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	require.ErrorIs(t, <-cancelledErr, context.Canceled)
+	require.NoError(t, <-result)
+	require.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestCoalescedRequestCancelledWithLastCaller(t *testing.T) {
+	c, calls, done := newSlowTestClient(t, time.Minute)
+	defer done()
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = c.Get(ctx, loc, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+
+	// A new caller starts a new request instead of joining the cancelled one
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel2()
+	_, err = c.Get(ctx2, loc, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
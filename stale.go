@@ -0,0 +1,92 @@
+package omgo
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+)
+
+// staleKeyPrefix separates the entries written in stale mode, which carry their own
+// freshness, from plain cache entries
+const staleKeyPrefix = "stale:"
+
+// staleRefreshTimeout bounds background refreshes, which are detached from the caller's context
+const staleRefreshTimeout = time.Minute
+
+// StalePolicy allows serving cached responses after they expired. Expired responses are
+// kept for Grace longer and served when the API can not be reached.
+//
+// With Revalidate set, expired responses are served immediately while being refreshed in
+// the background. Otherwise they are refreshed synchronously and only served when the
+// refresh fails. Responses served after expiry are marked as stale in the results
+type StalePolicy struct {
+	Grace      time.Duration // How long expired responses are kept, 0 disables stale serving
+	Revalidate bool          // Serve expired responses immediately and refresh them in the background
+}
+
+// getStale is the stale mode counterpart of the plain cache lookup in getURL
+func (c *Client) getStale(ctx context.Context, key string, url string, ttl time.Duration) ([]byte, bool, error) {
+	staleKey := staleKeyPrefix + key
+	data, freshUntil, found := c.getStaleEntry(staleKey)
+	if found && time.Now().Before(freshUntil) {
+		return data, false, nil
+	}
+
+	if found && c.Stale.Revalidate {
+		c.revalidate(staleKey, url, ttl)
+		return data, true, nil
+	}
+
+	body, err := c.fetchShared(ctx, key, url, func(body []byte) {
+		c.setStaleEntry(staleKey, body, ttl)
+	})
+	if err != nil {
+		if found && ctx.Err() == nil {
+			return data, true, nil
+		}
+		return nil, false, err
+	}
+
+	return body, false, nil
+}
+
+// revalidate refreshes url in the background, unless a refresh is already running
+func (c *Client) revalidate(key string, url string, ttl time.Duration) {
+	if c.revalidating != nil {
+		if _, running := c.revalidating.LoadOrStore(key, struct{}{}); running {
+			return
+		}
+	}
+
+	go func() {
+		if c.revalidating != nil {
+			defer c.revalidating.Delete(key)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), staleRefreshTimeout)
+		defer cancel()
+
+		// On failure the stale entry is kept, and served again until its grace period ends
+		if body, err := c.fetch(ctx, url); err == nil {
+			c.setStaleEntry(key, body, ttl)
+		}
+	}()
+}
+
+// Entries are stored as the end of their freshness in unix nanoseconds followed by the data,
+// and kept in the cache for the grace period beyond that
+func (c *Client) setStaleEntry(key string, data []byte, ttl time.Duration) {
+	raw := make([]byte, 8+len(data))
+	binary.BigEndian.PutUint64(raw[:8], uint64(time.Now().Add(ttl).UnixNano()))
+	copy(raw[8:], data)
+	c.Cache.Set(key, raw, ttl+c.Stale.Grace)
+}
+
+func (c *Client) getStaleEntry(key string) ([]byte, time.Time, bool) {
+	raw, found := c.Cache.Get(key)
+	if !found || len(raw) < 8 {
+		return nil, time.Time{}, false
+	}
+	freshUntil := time.Unix(0, int64(binary.BigEndian.Uint64(raw[:8])))
+	return raw[8:], freshUntil, true
+}
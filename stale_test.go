@@ -0,0 +1,192 @@
+package omgo_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+)
+
+// newStaleTestServer responds with the request count as latitude, or 503 once failing is set
+func newStaleTestServer(failing *int32) (*httptest.Server, *int32) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(failing) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"latitude": %d}`, n)
+	}))
+	return srv, &calls
+}
+
+func newStaleTestClient(t *testing.T, url string, stale omgo.StalePolicy) omgo.Client {
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ForecastEndpoint, url)
+	c.Retry = omgo.RetryPolicy{}
+	c.Stale = stale
+	return c
+}
+
+func TestStale_ServedOnError(t *testing.T) {
+	var failing int32
+	srv, _ := newStaleTestServer(&failing)
+	defer srv.Close()
+
+	c := newStaleTestClient(t, srv.URL, omgo.StalePolicy{Grace: time.Hour})
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+	opts := &omgo.Options{CacheTTL: 50 * time.Millisecond}
+
+	fc, err := c.Forecast(context.Background(), loc, opts)
+	require.NoError(t, err)
+	require.False(t, fc.Stale)
+	require.Equal(t, float64(1), fc.Latitude)
+
+	time.Sleep(100 * time.Millisecond)
+	atomic.StoreInt32(&failing, 1)
+
+	fc, err = c.Forecast(context.Background(), loc, opts)
+	require.NoError(t, err)
+	require.True(t, fc.Stale)
+	require.Equal(t, float64(1), fc.Latitude)
+}
+
+func TestStale_RefreshedWhenReachable(t *testing.T) {
+	var failing int32
+	srv, calls := newStaleTestServer(&failing)
+	defer srv.Close()
+
+	c := newStaleTestClient(t, srv.URL, omgo.StalePolicy{Grace: time.Hour})
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+	opts := &omgo.Options{CacheTTL: 50 * time.Millisecond}
+
+	_, err = c.Forecast(context.Background(), loc, opts)
+	require.NoError(t, err)
+
+	// Still fresh, served from the cache
+	fc, err := c.Forecast(context.Background(), loc, opts)
+	require.NoError(t, err)
+	require.False(t, fc.Stale)
+	require.Equal(t, int32(1), atomic.LoadInt32(calls))
+
+	time.Sleep(100 * time.Millisecond)
+
+	fc, err = c.Forecast(context.Background(), loc, opts)
+	require.NoError(t, err)
+	require.False(t, fc.Stale)
+	require.Equal(t, float64(2), fc.Latitude)
+}
+
+func TestStale_Revalidate(t *testing.T) {
+	var failing int32
+	srv, calls := newStaleTestServer(&failing)
+	defer srv.Close()
+
+	c := newStaleTestClient(t, srv.URL, omgo.StalePolicy{Grace: time.Hour, Revalidate: true})
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+	opts := &omgo.Options{CacheTTL: 200 * time.Millisecond}
+
+	_, err = c.Forecast(context.Background(), loc, opts)
+	require.NoError(t, err)
+
+	time.Sleep(250 * time.Millisecond)
+
+	// The expired response is served immediately, while refreshed in the background
+	fc, err := c.Forecast(context.Background(), loc, opts)
+	require.NoError(t, err)
+	require.True(t, fc.Stale)
+	require.Equal(t, float64(1), fc.Latitude)
+
+	require.Eventually(t, func() bool {
+		fc, err := c.Forecast(context.Background(), loc, opts)
+		return err == nil && !fc.Stale && fc.Latitude == 2
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestStale_NothingCached(t *testing.T) {
+	failing := int32(1)
+	srv, _ := newStaleTestServer(&failing)
+	defer srv.Close()
+
+	c := newStaleTestClient(t, srv.URL, omgo.StalePolicy{Grace: time.Hour})
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	_, err = c.Forecast(context.Background(), loc, nil)
+	require.IsType(t, omgo.ErrAPIResponse{}, err)
+}
+
+func TestStale_ReportedByEveryResult(t *testing.T) {
+	var failing int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		switch r.URL.Path {
+		case "/search":
+			_, _ = w.Write([]byte(`{"results": [{"name": "Amsterdam"}]}`))
+		case "/elevation":
+			_, _ = w.Write([]byte(`{"elevation": [2]}`))
+		default:
+			_, _ = w.Write([]byte(`{"latitude": 1}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := newStaleTestClient(t, srv.URL, omgo.StalePolicy{Grace: time.Hour})
+	for _, e := range []omgo.Endpoint{omgo.ArchiveEndpoint, omgo.SeasonalEndpoint, omgo.SatelliteEndpoint} {
+		c.SetEndpoint(e, srv.URL)
+	}
+	c.SetEndpoint(omgo.GeocodingEndpoint, srv.URL+"/search")
+	c.SetEndpoint(omgo.ElevationEndpoint, srv.URL+"/elevation")
+	c.TTL = omgo.TTLPolicy{Default: omgo.TTLRule{TTL: 50 * time.Millisecond}}
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	fetch := func(stale bool) {
+		_, meta, err := c.GetWithMeta(ctx, omgo.ForecastEndpoint, loc, nil)
+		require.NoError(t, err)
+		require.Equal(t, stale, meta.Stale)
+
+		historical, err := c.GetHistoricalData(ctx, loc, &omgo.Options{StartDate: "2024-01-01", EndDate: "2024-01-02"})
+		require.NoError(t, err)
+		require.Equal(t, stale, historical.Stale)
+
+		seasonal, err := c.GetSeasonalForecast(ctx, loc, &omgo.Options{SeasonalForecast: true, ForecastMonths: 1})
+		require.NoError(t, err)
+		require.Equal(t, stale, seasonal.Stale)
+
+		satellite, err := c.GetSatelliteData(ctx, loc, nil)
+		require.NoError(t, err)
+		require.Equal(t, stale, satellite.Stale)
+
+		places, err := c.Geocode(ctx, "Amsterdam", nil)
+		require.NoError(t, err)
+		require.Len(t, places, 1)
+		require.Equal(t, stale, places[0].Stale)
+
+		_, meta, err = c.ElevationWithMeta(ctx, []omgo.Location{loc}, nil)
+		require.NoError(t, err)
+		require.Equal(t, stale, meta.Stale)
+	}
+
+	fetch(false)
+	time.Sleep(100 * time.Millisecond)
+	atomic.StoreInt32(&failing, 1)
+	fetch(true)
+}
@@ -60,3 +60,30 @@ func (ct *ApiDate) MarshalJSON() ([]byte, error) {
 func (ct *ApiDate) IsSet() bool {
 	return ct.UnixNano() != nilTime
 }
+
+// responseLocation returns the location of the times in an API response. The API returns local
+// times without offset, in the timezone of the request (see Options.Timezone). The named zone is
+// preferred as it follows DST changes within the response, the fixed offset is used when the zone
+// database is not available
+func responseLocation(timezone, abbreviation string, utcOffsetSeconds int) *time.Location {
+	if utcOffsetSeconds == 0 && (timezone == "" || timezone == "GMT" || timezone == "UTC") {
+		return time.UTC
+	}
+	if timezone != "" {
+		if loc, err := time.LoadLocation(timezone); err == nil {
+			return loc
+		}
+	}
+	if abbreviation == "" {
+		abbreviation = timezone
+	}
+	return time.FixedZone(abbreviation, utcOffsetSeconds)
+}
+
+// inLocation returns the wall clock time of t, as parsed from the API, in loc
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	if t.IsZero() || loc == time.UTC {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
@@ -0,0 +1,64 @@
+package omgo
+
+import "time"
+
+// TTLRule describes how long a cached response stays valid
+type TTLRule struct {
+	TTL time.Duration // Fixed time to live, 0 disables caching unless Align is set
+	// Align makes entries expire at the next multiple of Align (in UTC), e.g. at the next
+	// full hour for hourly model updates. When TTL is set as well, the earliest expiry wins
+	Align time.Duration
+}
+
+// expiry returns the time to live of a response cached at now
+func (r TTLRule) expiry(now time.Time) time.Duration {
+	ttl := r.TTL
+	if r.Align > 0 {
+		next := now.Truncate(r.Align).Add(r.Align)
+		if d := next.Sub(now); ttl <= 0 || d < ttl {
+			ttl = d
+		}
+	}
+	return ttl
+}
+
+// TTLPolicy decides how long responses are cached. Requests can override it through
+// Options.CacheTTL
+type TTLPolicy struct {
+	Default   TTLRule              // Used for endpoints without a rule
+	Endpoints map[Endpoint]TTLRule // Rule per API family
+	Current   TTLRule              // Used for current conditions, see Client.CurrentWeather
+}
+
+// DefaultTTLPolicy returns the TTL policy used by NewClient. Archive and climate data is
+// cached for a long time as it rarely changes, model forecasts until the next model update
+// and current conditions for at most 15 minutes
+func DefaultTTLPolicy() TTLPolicy {
+	return TTLPolicy{
+		Default: TTLRule{TTL: 5 * time.Minute},
+		Endpoints: map[Endpoint]TTLRule{
+			ForecastEndpoint:           {TTL: time.Hour, Align: time.Hour},
+			MarineEndpoint:             {TTL: time.Hour, Align: time.Hour},
+			AirQualityEndpoint:         {TTL: time.Hour, Align: time.Hour},
+			SatelliteEndpoint:          {TTL: time.Hour, Align: time.Hour},
+			PreviousRunsEndpoint:       {TTL: time.Hour, Align: time.Hour},
+			EnsembleEndpoint:           {TTL: 6 * time.Hour, Align: 6 * time.Hour},
+			FloodEndpoint:              {TTL: 24 * time.Hour, Align: 24 * time.Hour},
+			SeasonalEndpoint:           {TTL: 24 * time.Hour, Align: 24 * time.Hour},
+			ArchiveEndpoint:            {TTL: 24 * time.Hour},
+			HistoricalForecastEndpoint: {TTL: 24 * time.Hour},
+			GeocodingEndpoint:          {TTL: 24 * time.Hour},
+			ClimateEndpoint:            {TTL: 30 * 24 * time.Hour},
+			ElevationEndpoint:          {TTL: 30 * 24 * time.Hour},
+		},
+		Current: TTLRule{TTL: 15 * time.Minute, Align: 15 * time.Minute},
+	}
+}
+
+// For returns the time to live of a response of the given endpoint cached at now
+func (p TTLPolicy) For(e Endpoint, now time.Time) time.Duration {
+	if r, ok := p.Endpoints[e]; ok {
+		return r.expiry(now)
+	}
+	return p.Default.expiry(now)
+}
@@ -0,0 +1,132 @@
+package omgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTTLPolicy(t *testing.T) {
+	p := omgo.DefaultTTLPolicy()
+	now := time.Date(2024, time.September, 10, 14, 20, 0, 0, time.UTC)
+
+	// Forecasts expire at the next model update
+	require.Equal(t, 40*time.Minute, p.For(omgo.ForecastEndpoint, now))
+	require.Equal(t, 3*time.Hour+40*time.Minute, p.For(omgo.EnsembleEndpoint, now))
+	// Archive data is cached for a day
+	require.Equal(t, 24*time.Hour, p.For(omgo.ArchiveEndpoint, now))
+	// Unknown endpoints fall back to the default
+	require.Equal(t, 5*time.Minute, p.For(omgo.Endpoint("custom"), now))
+}
+
+func TestTTLRule_AlignCappedByTTL(t *testing.T) {
+	p := omgo.TTLPolicy{
+		Endpoints: map[omgo.Endpoint]omgo.TTLRule{
+			omgo.ForecastEndpoint: {TTL: 10 * time.Minute, Align: time.Hour},
+		},
+	}
+	now := time.Date(2024, time.September, 10, 14, 20, 0, 0, time.UTC)
+	require.Equal(t, 10*time.Minute, p.For(omgo.ForecastEndpoint, now))
+	require.Equal(t, time.Duration(0), p.For(omgo.ArchiveEndpoint, now))
+}
+
+type ttlRecordingCache struct {
+	omgo.NoopCache
+	mu   sync.Mutex
+	ttls []time.Duration
+}
+
+func (c *ttlRecordingCache) Set(key string, data []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttls = append(c.ttls, ttl)
+}
+
+func TestClientCacheTTL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	cache := &ttlRecordingCache{}
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.Cache = cache
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+	c.SetEndpoint(omgo.ArchiveEndpoint, srv.URL)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	_, err = c.GetFrom(context.Background(), omgo.ArchiveEndpoint, loc, nil)
+	require.NoError(t, err)
+	_, err = c.Get(context.Background(), loc, &omgo.Options{CacheTTL: 42 * time.Second})
+	require.NoError(t, err)
+	_, err = c.CurrentWeather(context.Background(), loc, nil)
+	require.NoError(t, err)
+	_, err = c.Get(context.Background(), loc, &omgo.Options{CacheTTL: -1})
+	require.NoError(t, err)
+
+	require.Len(t, cache.ttls, 3)
+	require.Equal(t, 24*time.Hour, cache.ttls[0])
+	require.Equal(t, 42*time.Second, cache.ttls[1])
+	require.LessOrEqual(t, cache.ttls[2], 15*time.Minute)
+}
+
+func TestClientCacheTTL_AllAPIs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/elevation" {
+			_, _ = w.Write([]byte(`{"elevation": [2.0]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"hourly": {"time": []}}`))
+	}))
+	defer srv.Close()
+
+	cache := &ttlRecordingCache{}
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.Cache = cache
+	for _, e := range []omgo.Endpoint{omgo.MarineEndpoint, omgo.FloodEndpoint, omgo.ClimateEndpoint,
+		omgo.EnsembleEndpoint, omgo.GeocodingEndpoint, omgo.AirQualityEndpoint} {
+		c.SetEndpoint(e, srv.URL)
+	}
+	c.SetEndpoint(omgo.ElevationEndpoint, srv.URL+"/elevation")
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ttl := 42 * time.Second
+	_, err = c.Marine(ctx, loc, &omgo.MarineOptions{CacheTTL: ttl})
+	require.NoError(t, err)
+	_, err = c.Flood(ctx, loc, &omgo.FloodOptions{CacheTTL: ttl})
+	require.NoError(t, err)
+	_, err = c.ClimateProjection(ctx, loc, &omgo.ClimateOptions{
+		Models:       []string{omgo.ClimateModelCMCC_CM2_VHR4},
+		StartDate:    "2030-01-01",
+		EndDate:      "2030-01-31",
+		DailyMetrics: []string{"temperature_2m_max"},
+		CacheTTL:     ttl,
+	})
+	require.NoError(t, err)
+	_, err = c.Ensemble(ctx, loc, &omgo.EnsembleOptions{HourlyMetrics: []string{"temperature_2m"}, CacheTTL: ttl})
+	require.NoError(t, err)
+	_, err = c.Geocode(ctx, "Amsterdam", &omgo.GeocodeOptions{CacheTTL: ttl})
+	require.NoError(t, err)
+	_, err = c.GetAirQuality(ctx, loc, &omgo.AirQualityOptions{CacheTTL: ttl})
+	require.NoError(t, err)
+	_, _, err = c.ElevationWithMeta(ctx, []omgo.Location{loc}, &omgo.ElevationOptions{CacheTTL: ttl})
+	require.NoError(t, err)
+
+	require.Len(t, cache.ttls, 7)
+	for _, got := range cache.ttls {
+		require.Equal(t, ttl, got)
+	}
+}
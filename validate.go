@@ -0,0 +1,121 @@
+package omgo
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxPastDays is the largest number of past days the forecast API returns
+const MaxPastDays = 92
+
+// dailyAggregations are the suffixes of daily aggregations of hourly variables, e.g.
+// "cloud_cover_mean" or "relative_humidity_2m_max" in the archive API
+var dailyAggregations = []string{"_max", "_min", "_mean", "_sum", "_dominant"}
+
+// Validate checks the options before they are sent, and returns every problem at once as
+// ErrInvalidOptions. Variables are checked against the constants of this package, including
+// pressure level variables and the "_previous_dayN" variants of the previous runs API, unless
+// AllowUnknownVariables is set
+func (o *Options) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	var errs ErrInvalidOptions
+	invalid := func(param string, value interface{}) {
+		errs = append(errs, ErrInvalidInput{Param: param, Value: value})
+	}
+
+	if o.TemperatureUnit != "" && !temperatureUnits[o.TemperatureUnit] {
+		invalid("temperature_unit", o.TemperatureUnit)
+	}
+	if o.WindspeedUnit != "" && !windspeedUnits[o.WindspeedUnit] {
+		invalid("windspeed_unit", o.WindspeedUnit)
+	}
+	if o.PrecipitationUnit != "" && !precipitationUnits[o.PrecipitationUnit] {
+		invalid("precipitation_unit", o.PrecipitationUnit)
+	}
+	if o.PastDays < 0 || o.PastDays > MaxPastDays {
+		invalid("past_days", o.PastDays)
+	}
+	if o.ForecastMonths < 0 || o.ForecastMonths > 6 {
+		invalid("forecast_months", o.ForecastMonths)
+	}
+
+	if !o.AllowUnknownVariables {
+		for _, m := range o.HourlyMetrics {
+			if !validHourlyVariable(m) {
+				invalid("hourly", m)
+			}
+		}
+		for _, m := range o.DailyMetrics {
+			if !validDailyVariable(m) {
+				invalid("daily", m)
+			}
+		}
+		for _, m := range o.CurrentMetrics {
+			if !currentVariables[m] && !validHourlyVariable(m) {
+				invalid("current", m)
+			}
+		}
+		for _, m := range o.Minutely15Metrics {
+			if !minutely15Variables[m] {
+				invalid("minutely_15", m)
+			}
+		}
+	}
+
+	start, startErr := time.Parse("2006-01-02", o.StartDate)
+	if o.StartDate != "" && startErr != nil {
+		invalid("start_date", o.StartDate)
+	}
+	end, endErr := time.Parse("2006-01-02", o.EndDate)
+	if o.EndDate != "" && endErr != nil {
+		invalid("end_date", o.EndDate)
+	}
+	if startErr == nil && endErr == nil && end.Before(start) {
+		invalid("end_date", o.EndDate)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validHourlyVariable(name string) bool {
+	name, _, _ = splitPreviousDayKey(name)
+	if hourlyVariables[name] {
+		return true
+	}
+
+	// Pressure level variables, e.g. "temperature_850hPa"
+	i := strings.LastIndex(name, "_")
+	if i < 0 || !strings.HasSuffix(name, "hPa") || !pressureVariables[name[:i]] {
+		return false
+	}
+	level, err := strconv.Atoi(strings.TrimSuffix(name[i+1:], "hPa"))
+	if err != nil {
+		return false
+	}
+	for _, l := range PressureLevels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+func validDailyVariable(name string) bool {
+	name, _, _ = splitPreviousDayKey(name)
+	if dailyVariables[name] {
+		return true
+	}
+	for _, suffix := range dailyAggregations {
+		if strings.HasSuffix(name, suffix) && hourlyVariables[strings.TrimSuffix(name, suffix)] {
+			return true
+		}
+	}
+	return false
+}
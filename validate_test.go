@@ -0,0 +1,140 @@
+package omgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	var nilOpts *omgo.Options
+	require.NoError(t, nilOpts.Validate())
+
+	opts := omgo.Options{
+		TemperatureUnit:   omgo.TemperatureUnitFahrenheit,
+		WindspeedUnit:     omgo.WindspeedUnitKnots,
+		PrecipitationUnit: omgo.PrecipitationUnitInch,
+		PastDays:          92,
+		HourlyMetrics: []string{
+			omgo.HourlyTemperature2m,
+			omgo.PressureLevelVariable(omgo.PressureTemperature, 850),
+			"temperature_2m_previous_day3",
+		},
+		DailyMetrics:      []string{omgo.DailyTemperature2mMax, "cloud_cover_mean"},
+		CurrentMetrics:    []string{omgo.CurrentIsDay, omgo.HourlyVisibility},
+		Minutely15Metrics: []string{omgo.Minutely15LightningPotential},
+		StartDate:         "2024-01-01",
+		EndDate:           "2024-01-01",
+	}
+	require.NoError(t, opts.Validate())
+}
+
+func TestOptionsValidateReportsAllProblems(t *testing.T) {
+	opts := omgo.Options{
+		TemperatureUnit:   "kelvin",
+		WindspeedUnit:     "km/h",
+		PrecipitationUnit: "cm",
+		PastDays:          93,
+		HourlyMetrics:     []string{"relativehumidity_2m", "temperature_851hPa"},
+		DailyMetrics:      []string{"temperature_max"},
+		CurrentMetrics:    []string{"cloudcover"},
+		Minutely15Metrics: []string{"soil_moisture_0_to_1cm"},
+		StartDate:         "2024-02-01",
+		EndDate:           "2024-01-31",
+	}
+
+	err := opts.Validate()
+	var invalid omgo.ErrInvalidOptions
+	require.True(t, errors.As(err, &invalid))
+	require.Equal(t, omgo.ErrInvalidOptions{
+		{Param: "temperature_unit", Value: "kelvin"},
+		{Param: "windspeed_unit", Value: "km/h"},
+		{Param: "precipitation_unit", Value: "cm"},
+		{Param: "past_days", Value: 93},
+		{Param: "hourly", Value: "relativehumidity_2m"},
+		{Param: "hourly", Value: "temperature_851hPa"},
+		{Param: "daily", Value: "temperature_max"},
+		{Param: "current", Value: "cloudcover"},
+		{Param: "minutely_15", Value: "soil_moisture_0_to_1cm"},
+		{Param: "end_date", Value: "2024-01-31"},
+	}, invalid)
+	require.True(t, errors.Is(err, omgo.ErrInvalidParameter))
+	require.Contains(t, err.Error(), "invalid input: hourly = relativehumidity_2m; ")
+}
+
+func TestErrInvalidOptionsIsAs(t *testing.T) {
+	err := omgo.ErrInvalidOptions{
+		{Param: "past_days", Value: 93},
+		{Param: "hourly", Value: "cloudcover"},
+	}
+
+	// Called directly, as toolchains before Go 1.20 don't unwrap []error
+	require.True(t, err.Is(omgo.ErrInvalidParameter))
+	require.True(t, err.Is(omgo.ErrInvalidInput{Param: "hourly", Value: "cloudcover"}))
+	require.False(t, err.Is(omgo.ErrInvalidInput{Param: "daily", Value: "cloudcover"}))
+	require.False(t, err.Is(omgo.ErrUnknownVariable))
+
+	var input omgo.ErrInvalidInput
+	require.True(t, err.As(&input))
+	require.Equal(t, omgo.ErrInvalidInput{Param: "past_days", Value: 93}, input)
+	require.False(t, err.As(&omgo.ErrAPIResponse{}))
+}
+
+func TestOptionsValidateDates(t *testing.T) {
+	opts := omgo.Options{StartDate: "2024-13-01", EndDate: "yesterday"}
+
+	var invalid omgo.ErrInvalidOptions
+	require.True(t, errors.As(opts.Validate(), &invalid))
+	require.Equal(t, omgo.ErrInvalidOptions{
+		{Param: "start_date", Value: "2024-13-01"},
+		{Param: "end_date", Value: "yesterday"},
+	}, invalid)
+}
+
+func TestOptionsValidateAllowUnknownVariables(t *testing.T) {
+	opts := omgo.Options{
+		HourlyMetrics:         []string{"some_new_variable"},
+		DailyMetrics:          []string{"some_new_variable_max"},
+		AllowUnknownVariables: true,
+	}
+	require.NoError(t, opts.Validate())
+
+	// Everything but the variables is still checked
+	opts.PastDays = -1
+	var invalid omgo.ErrInvalidOptions
+	require.True(t, errors.As(opts.Validate(), &invalid))
+	require.Equal(t, omgo.ErrInvalidOptions{{Param: "past_days", Value: -1}}, invalid)
+}
+
+func TestClientValidatesBeforeSending(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c, err := omgo.NewClient()
+	require.NoError(t, err)
+	c.SetEndpoint(omgo.ForecastEndpoint, srv.URL)
+
+	loc, err := omgo.NewLocation(52.3738, 4.8910) // Amsterdam
+	require.NoError(t, err)
+
+	_, err = c.Forecast(context.Background(), loc, &omgo.Options{HourlyMetrics: []string{"dewpoint_2m"}})
+	require.True(t, errors.Is(err, omgo.ErrInvalidParameter))
+	require.Equal(t, 0, calls)
+
+	_, err = c.Forecast(context.Background(), loc, &omgo.Options{HourlyMetrics: []string{omgo.HourlyDewPoint2m}})
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+
+	_, err = c.Forecast(context.Background(), loc, &omgo.Options{HourlyMetrics: []string{"dewpoint_2m"}, AllowUnknownVariables: true})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+}
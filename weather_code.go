@@ -0,0 +1,162 @@
+package omgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// WeatherCode is a WMO weather interpretation code, as returned in the weather_code variables
+type WeatherCode int
+
+const (
+	WeatherCodeClearSky               WeatherCode = 0
+	WeatherCodeMainlyClear            WeatherCode = 1
+	WeatherCodePartlyCloudy           WeatherCode = 2
+	WeatherCodeOvercast               WeatherCode = 3
+	WeatherCodeFog                    WeatherCode = 45
+	WeatherCodeDepositingRimeFog      WeatherCode = 48
+	WeatherCodeLightDrizzle           WeatherCode = 51
+	WeatherCodeModerateDrizzle        WeatherCode = 53
+	WeatherCodeDenseDrizzle           WeatherCode = 55
+	WeatherCodeLightFreezingDrizzle   WeatherCode = 56
+	WeatherCodeDenseFreezingDrizzle   WeatherCode = 57
+	WeatherCodeSlightRain             WeatherCode = 61
+	WeatherCodeModerateRain           WeatherCode = 63
+	WeatherCodeHeavyRain              WeatherCode = 65
+	WeatherCodeLightFreezingRain      WeatherCode = 66
+	WeatherCodeHeavyFreezingRain      WeatherCode = 67
+	WeatherCodeSlightSnowFall         WeatherCode = 71
+	WeatherCodeModerateSnowFall       WeatherCode = 73
+	WeatherCodeHeavySnowFall          WeatherCode = 75
+	WeatherCodeSnowGrains             WeatherCode = 77
+	WeatherCodeSlightRainShowers      WeatherCode = 80
+	WeatherCodeModerateRainShowers    WeatherCode = 81
+	WeatherCodeViolentRainShowers     WeatherCode = 82
+	WeatherCodeSlightSnowShowers      WeatherCode = 85
+	WeatherCodeHeavySnowShowers       WeatherCode = 86
+	WeatherCodeThunderstorm           WeatherCode = 95
+	WeatherCodeThunderstormSlightHail WeatherCode = 96
+	WeatherCodeThunderstormHeavyHail  WeatherCode = 99
+)
+
+// WeatherCodeUnknown marks a missing observation, decoded from null. It is not a WMO code
+const WeatherCodeUnknown WeatherCode = -1
+
+// WeatherCategory groups weather codes into coarse conditions
+type WeatherCategory string
+
+const (
+	WeatherCategoryClear        WeatherCategory = "clear"
+	WeatherCategoryCloudy       WeatherCategory = "cloudy"
+	WeatherCategoryFog          WeatherCategory = "fog"
+	WeatherCategoryDrizzle      WeatherCategory = "drizzle"
+	WeatherCategoryRain         WeatherCategory = "rain"
+	WeatherCategorySnow         WeatherCategory = "snow"
+	WeatherCategoryThunderstorm WeatherCategory = "thunderstorm"
+)
+
+type weatherCodeInfo struct {
+	description string
+	category    WeatherCategory
+	severity    int
+	dayIcon     string
+	nightIcon   string
+}
+
+var weatherCodes = map[WeatherCode]weatherCodeInfo{
+	WeatherCodeClearSky:               {"Clear sky", WeatherCategoryClear, 0, "clear-day", "clear-night"},
+	WeatherCodeMainlyClear:            {"Mainly clear", WeatherCategoryClear, 0, "mostly-clear-day", "mostly-clear-night"},
+	WeatherCodePartlyCloudy:           {"Partly cloudy", WeatherCategoryCloudy, 1, "partly-cloudy-day", "partly-cloudy-night"},
+	WeatherCodeOvercast:               {"Overcast", WeatherCategoryCloudy, 1, "overcast", "overcast"},
+	WeatherCodeFog:                    {"Fog", WeatherCategoryFog, 2, "fog", "fog"},
+	WeatherCodeDepositingRimeFog:      {"Depositing rime fog", WeatherCategoryFog, 3, "fog", "fog"},
+	WeatherCodeLightDrizzle:           {"Light drizzle", WeatherCategoryDrizzle, 2, "drizzle", "drizzle"},
+	WeatherCodeModerateDrizzle:        {"Moderate drizzle", WeatherCategoryDrizzle, 3, "drizzle", "drizzle"},
+	WeatherCodeDenseDrizzle:           {"Dense drizzle", WeatherCategoryDrizzle, 4, "drizzle", "drizzle"},
+	WeatherCodeLightFreezingDrizzle:   {"Light freezing drizzle", WeatherCategoryDrizzle, 4, "freezing-drizzle", "freezing-drizzle"},
+	WeatherCodeDenseFreezingDrizzle:   {"Dense freezing drizzle", WeatherCategoryDrizzle, 5, "freezing-drizzle", "freezing-drizzle"},
+	WeatherCodeSlightRain:             {"Slight rain", WeatherCategoryRain, 3, "rain", "rain"},
+	WeatherCodeModerateRain:           {"Moderate rain", WeatherCategoryRain, 4, "rain", "rain"},
+	WeatherCodeHeavyRain:              {"Heavy rain", WeatherCategoryRain, 6, "heavy-rain", "heavy-rain"},
+	WeatherCodeLightFreezingRain:      {"Light freezing rain", WeatherCategoryRain, 6, "freezing-rain", "freezing-rain"},
+	WeatherCodeHeavyFreezingRain:      {"Heavy freezing rain", WeatherCategoryRain, 7, "freezing-rain", "freezing-rain"},
+	WeatherCodeSlightSnowFall:         {"Slight snow fall", WeatherCategorySnow, 3, "snow", "snow"},
+	WeatherCodeModerateSnowFall:       {"Moderate snow fall", WeatherCategorySnow, 5, "snow", "snow"},
+	WeatherCodeHeavySnowFall:          {"Heavy snow fall", WeatherCategorySnow, 7, "heavy-snow", "heavy-snow"},
+	WeatherCodeSnowGrains:             {"Snow grains", WeatherCategorySnow, 3, "snow", "snow"},
+	WeatherCodeSlightRainShowers:      {"Slight rain showers", WeatherCategoryRain, 3, "rain-showers-day", "rain-showers-night"},
+	WeatherCodeModerateRainShowers:    {"Moderate rain showers", WeatherCategoryRain, 4, "rain-showers-day", "rain-showers-night"},
+	WeatherCodeViolentRainShowers:     {"Violent rain showers", WeatherCategoryRain, 7, "heavy-rain", "heavy-rain"},
+	WeatherCodeSlightSnowShowers:      {"Slight snow showers", WeatherCategorySnow, 4, "snow-showers-day", "snow-showers-night"},
+	WeatherCodeHeavySnowShowers:       {"Heavy snow showers", WeatherCategorySnow, 6, "heavy-snow", "heavy-snow"},
+	WeatherCodeThunderstorm:           {"Thunderstorm", WeatherCategoryThunderstorm, 8, "thunderstorm", "thunderstorm"},
+	WeatherCodeThunderstormSlightHail: {"Thunderstorm with slight hail", WeatherCategoryThunderstorm, 9, "thunderstorm-hail", "thunderstorm-hail"},
+	WeatherCodeThunderstormHeavyHail:  {"Thunderstorm with heavy hail", WeatherCategoryThunderstorm, 10, "thunderstorm-hail", "thunderstorm-hail"},
+}
+
+// Known reports whether the code is one of the WMO codes emitted by Open-Meteo
+func (w WeatherCode) Known() bool {
+	_, ok := weatherCodes[w]
+	return ok
+}
+
+// Description returns a human readable description, e.g. "Moderate rain"
+func (w WeatherCode) Description() string {
+	if info, ok := weatherCodes[w]; ok {
+		return info.description
+	}
+	return fmt.Sprintf("Unknown weather code %d", int(w))
+}
+
+func (w WeatherCode) String() string {
+	return w.Description()
+}
+
+// Category returns the coarse condition of the code, empty for unknown codes
+func (w WeatherCode) Category() WeatherCategory {
+	return weatherCodes[w].category
+}
+
+// Severity orders codes from 0 (clear) to 10 (thunderstorm with heavy hail). Unlike the codes
+// themselves, severities compare across categories, e.g. heavy rain is more severe than slight
+// rain showers. Unknown codes return -1
+func (w WeatherCode) Severity() int {
+	if info, ok := weatherCodes[w]; ok {
+		return info.severity
+	}
+	return -1
+}
+
+// Icon returns an icon identifier for the code, e.g. "partly-cloudy-day", empty for unknown codes.
+// Codes without a day and night variant return the same identifier for both
+func (w WeatherCode) Icon(isDay bool) string {
+	if isDay {
+		return weatherCodes[w].dayIcon
+	}
+	return weatherCodes[w].nightIcon
+}
+
+// UnmarshalJSON accepts codes sent as floats, e.g. 3.0. Null values decode to WeatherCodeUnknown,
+// as 0 would read as a clear sky
+func (w *WeatherCode) UnmarshalJSON(data []byte) error {
+	var f *float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	if f == nil {
+		*w = WeatherCodeUnknown
+		return nil
+	}
+	*w = WeatherCode(math.Round(*f))
+	return nil
+}
+
+// WeatherCodes converts a weather_code series of Forecast.HourlyMetrics or DailyMetrics
+func WeatherCodes(values []float64) []WeatherCode {
+	codes := make([]WeatherCode, len(values))
+	for i, v := range values {
+		codes[i] = WeatherCode(math.Round(v))
+	}
+	return codes
+}
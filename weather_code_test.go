@@ -0,0 +1,96 @@
+package omgo_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jdotcurs/omgo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeatherCode(t *testing.T) {
+	code := omgo.WeatherCodePartlyCloudy
+	require.True(t, code.Known())
+	require.Equal(t, "Partly cloudy", code.Description())
+	require.Equal(t, "Partly cloudy", code.String())
+	require.Equal(t, omgo.WeatherCategoryCloudy, code.Category())
+	require.Equal(t, "partly-cloudy-day", code.Icon(true))
+	require.Equal(t, "partly-cloudy-night", code.Icon(false))
+
+	require.Equal(t, omgo.WeatherCategoryRain, omgo.WeatherCodeSlightRainShowers.Category())
+	require.Equal(t, omgo.WeatherCategorySnow, omgo.WeatherCodeSnowGrains.Category())
+	require.Equal(t, omgo.WeatherCategoryThunderstorm, omgo.WeatherCodeThunderstormHeavyHail.Category())
+}
+
+func TestWeatherCodeDescriptions(t *testing.T) {
+	// WMO weather interpretation codes, as documented at https://open-meteo.com/en/docs
+	wmo := map[omgo.WeatherCode]string{
+		0:  "Clear sky",
+		1:  "Mainly clear",
+		2:  "Partly cloudy",
+		3:  "Overcast",
+		45: "Fog",
+		48: "Depositing rime fog",
+		51: "Light drizzle",
+		53: "Moderate drizzle",
+		55: "Dense drizzle",
+		56: "Light freezing drizzle",
+		57: "Dense freezing drizzle",
+		61: "Slight rain",
+		63: "Moderate rain",
+		65: "Heavy rain",
+		66: "Light freezing rain",
+		67: "Heavy freezing rain",
+		71: "Slight snow fall",
+		73: "Moderate snow fall",
+		75: "Heavy snow fall",
+		77: "Snow grains",
+		80: "Slight rain showers",
+		81: "Moderate rain showers",
+		82: "Violent rain showers",
+		85: "Slight snow showers",
+		86: "Heavy snow showers",
+		95: "Thunderstorm",
+		96: "Thunderstorm with slight hail",
+		99: "Thunderstorm with heavy hail",
+	}
+
+	for code := omgo.WeatherCode(0); code < 100; code++ {
+		description, ok := wmo[code]
+		require.Equal(t, ok, code.Known(), "code %d", code)
+		if ok {
+			require.Equal(t, description, code.Description(), "code %d", code)
+		}
+	}
+}
+
+func TestWeatherCodeSeverity(t *testing.T) {
+	require.Equal(t, 0, omgo.WeatherCodeClearSky.Severity())
+	require.Greater(t, omgo.WeatherCodeHeavyRain.Severity(), omgo.WeatherCodeSlightRainShowers.Severity())
+	require.Greater(t, omgo.WeatherCodeThunderstormHeavyHail.Severity(), omgo.WeatherCodeThunderstormSlightHail.Severity())
+	require.Greater(t, omgo.WeatherCodeThunderstorm.Severity(), omgo.WeatherCodeHeavySnowFall.Severity())
+}
+
+func TestWeatherCodeUnknown(t *testing.T) {
+	code := omgo.WeatherCode(42)
+	require.False(t, code.Known())
+	require.Equal(t, "Unknown weather code 42", code.Description())
+	require.Equal(t, omgo.WeatherCategory(""), code.Category())
+	require.Equal(t, -1, code.Severity())
+	require.Equal(t, "", code.Icon(true))
+}
+
+func TestWeatherCodeUnmarshalJSON(t *testing.T) {
+	var codes []omgo.WeatherCode
+	require.NoError(t, json.Unmarshal([]byte(`[0, 3.0, 95, null]`), &codes))
+	require.Equal(t, []omgo.WeatherCode{omgo.WeatherCodeClearSky, omgo.WeatherCodeOvercast, omgo.WeatherCodeThunderstorm, omgo.WeatherCodeUnknown}, codes)
+	require.False(t, codes[3].Known())
+	require.Equal(t, -1, codes[3].Severity())
+
+	var code omgo.WeatherCode
+	require.Error(t, json.Unmarshal([]byte(`"rain"`), &code))
+}
+
+func TestWeatherCodes(t *testing.T) {
+	require.Equal(t, []omgo.WeatherCode{omgo.WeatherCodeMainlyClear, omgo.WeatherCodeFog}, omgo.WeatherCodes([]float64{1, 45}))
+}